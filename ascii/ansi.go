@@ -0,0 +1,44 @@
+package ascii
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ansiEscape matches CSI sequences (colors, cursor movement) and OSC
+// sequences (titles, hyperlinks)
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// stripANSI removes ANSI escape sequences from s
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// Width returns the number of terminal cells a line of art occupies,
+// ignoring ANSI escape sequences and counting wide characters as two
+func Width(line string) int {
+	return runewidth.StringWidth(stripANSI(line))
+}
+
+// tabWidth is the distance between tab stops when expanding tabs
+const tabWidth = 8
+
+// expandTabs replaces tabs in line with spaces up to the next tab stop, so
+// the line's width doesn't depend on the terminal's tab handling. ANSI
+// escape sequences don't count towards the column.
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+
+	var b strings.Builder
+	for i, part := range strings.Split(line, "\t") {
+		if i > 0 {
+			b.WriteString(strings.Repeat(" ", tabWidth-Width(b.String())%tabWidth))
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
@@ -1,20 +1,142 @@
 package ascii
 
 import (
+	"embed"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// assets holds the bundled art, so the binary works from any directory
+//
+//go:embed assets/*.txt
+var assets embed.FS
+
+// SearchDirs lists the directories searched, in order, for "<name>.txt"
+// before falling back to the embedded assets. It starts with
+// ~/.config/ng-fetch/art.
+var SearchDirs = defaultSearchDirs()
+
+// defaultSearchDirs returns the user's art directory, or nothing when the
+// home directory is unknown
+func defaultSearchDirs() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{filepath.Join(home, ".config", "ng-fetch", "art")}
+}
+
+// Art sizes accepted by LoadASCIIArtSize
+const (
+	SizeSmall = "small"
+	SizeLarge = "large"
 )
 
-func PrintASCIIArt(filename string) {
-	path := filepath.Join("ascii", "assets", filename+".txt")
-	data, err := os.ReadFile(path)
+// PrintASCIIArt writes the named art from SearchDirs or the embedded assets
+// to w in the given size, tinted with tint unless it is nil
+func PrintASCIIArt(w io.Writer, filename, size string, tint *color.Color) {
+	art, err := LoadASCIIArtSize(filename, size)
 	if err != nil {
-		fmt.Println("Error loading ASCII art:", err)
+		fmt.Fprintln(w, "Error loading ASCII art:", err)
 		return
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(w, Colorize(art, tint))
+}
+
+// PrintASCIIArtFromPath writes art read from an arbitrary file to w, falling
+// back to the default art when the file can't be read
+func PrintASCIIArtFromPath(w io.Writer, path, size string, tint *color.Color) {
+	art, err := LoadASCIIArtFromPath(path)
+	if err != nil {
+		fmt.Fprintf(w, "Error loading ASCII art from %s: %v (using default art)\n", path, err)
+		PrintASCIIArt(w, "default", size, tint)
+		return
+	}
+	fmt.Fprintln(w, Colorize(art, tint))
+}
+
+// LoadASCIIArt returns the named art from the first of SearchDirs that has
+// it, or from the embedded assets
+func LoadASCIIArt(filename string) (string, error) {
+	return LoadASCIIArtIn(SearchDirs, filename)
+}
+
+// LoadASCIIArtIn is like LoadASCIIArt but searches dirs instead of
+// SearchDirs
+func LoadASCIIArtIn(dirs []string, filename string) (string, error) {
+	for _, dir := range dirs {
+		if data, err := os.ReadFile(filepath.Join(dir, filename+".txt")); err == nil {
+			return prepareArt(data), nil
+		}
+	}
 
+	data, err := assets.ReadFile("assets/" + filename + ".txt")
+	if err != nil {
+		return "", err
+	}
+	return prepareArt(data), nil
+}
+
+// LoadASCIIArtSize returns the named art in the given size. Small art is
+// stored as "<name>_small.txt"; the full art is used when a name has no
+// small variant.
+func LoadASCIIArtSize(filename, size string) (string, error) {
+	return LoadASCIIArtSizeIn(SearchDirs, filename, size)
+}
+
+// LoadASCIIArtSizeIn is like LoadASCIIArtSize but searches dirs instead of
+// SearchDirs
+func LoadASCIIArtSizeIn(dirs []string, filename, size string) (string, error) {
+	if size == SizeSmall {
+		if art, err := LoadASCIIArtIn(dirs, filename+"_small"); err == nil {
+			return art, nil
+		}
+	}
+	return LoadASCIIArtIn(dirs, filename)
+}
+
+// LoadASCIIArtFromPath returns the art stored in an arbitrary file, or read
+// from stdin when path is "-"
+func LoadASCIIArtFromPath(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return prepareArt(data), nil
+}
+
+// prepareArt converts raw art to a string, stripping ANSI color codes when
+// colors are disabled
+func prepareArt(data []byte) string {
+	art := string(data)
+	if color.NoColor {
+		art = stripANSI(art)
+	}
+	return art
+}
+
+// SplitLines splits art into lines, dropping carriage returns and trailing
+// blank lines and expanding tabs so every line's width can be measured
+func SplitLines(art string) []string {
+	lines := strings.Split(strings.ReplaceAll(art, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+	return lines
 }
 
 //func PrintASCIIArt(filename string) {
@@ -0,0 +1,63 @@
+package ascii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// colorNames maps the color names accepted by ParseColor to attributes
+var colorNames = map[string]color.Attribute{
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+
+	"bright-black":   color.FgHiBlack,
+	"bright-red":     color.FgHiRed,
+	"bright-green":   color.FgHiGreen,
+	"bright-yellow":  color.FgHiYellow,
+	"bright-blue":    color.FgHiBlue,
+	"bright-magenta": color.FgHiMagenta,
+	"bright-cyan":    color.FgHiCyan,
+	"bright-white":   color.FgHiWhite,
+}
+
+// ParseColor parses a color name such as "cyan" or "bright-blue", or a
+// "#rrggbb" hex color
+func ParseColor(value string) (*color.Color, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if attr, ok := colorNames[name]; ok {
+		return color.New(attr), nil
+	}
+
+	hex := strings.TrimPrefix(name, "#")
+	if len(hex) == 6 {
+		if rgb, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return color.RGB(int(rgb>>16&0xFF), int(rgb>>8&0xFF), int(rgb&0xFF)), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid color %q (use a name like \"cyan\" or \"bright-blue\", or a hex color like \"#ff8800\")", value)
+}
+
+// Colorize wraps every line of art in c, leaving it unchanged when c is nil
+// or colors are disabled
+func Colorize(art string, c *color.Color) string {
+	if c == nil {
+		return art
+	}
+
+	lines := strings.Split(art, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = c.Sprint(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,47 @@
+package ascii
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// distroArt maps /etc/os-release IDs to asset names
+var distroArt = map[string]string{
+	"ubuntu": "ubuntu",
+	"arch":   "arch",
+	"fedora": "fedora",
+	"debian": "debian",
+}
+
+// DetectDistroArt returns the asset name matching the running distribution,
+// or "default" when the distribution is unknown
+func DetectDistroArt() string {
+	return DistroArt(readOSReleaseID())
+}
+
+// DistroArt returns the asset name for an /etc/os-release ID, e.g. one read
+// from a remote host, or "default" when the distribution is unknown
+func DistroArt(id string) string {
+	if art, ok := distroArt[strings.ToLower(id)]; ok {
+		return art
+	}
+	return "default"
+}
+
+func readOSReleaseID() string {
+	file, err := os.Open("/etc/os-release")
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "ID=") {
+			return strings.ToLower(strings.Trim(strings.TrimPrefix(line, "ID="), `"'`))
+		}
+	}
+	return ""
+}
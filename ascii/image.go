@@ -0,0 +1,97 @@
+package ascii
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg" // register JPEG decoding
+	_ "image/png"  // register PNG decoding
+	"os"
+	"strings"
+)
+
+// luminanceRamp orders characters from lightest to densest coverage, so
+// brighter pixels use denser characters on a dark terminal background
+const luminanceRamp = " .:-=+*#%@"
+
+// ImageToASCII renders a PNG or JPEG image as ASCII art the given number of
+// characters wide. Fully transparent pixels become spaces.
+func ImageToASCII(path string, width int) (string, error) {
+	if width <= 0 {
+		return "", fmt.Errorf("invalid width %d", width)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %v", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return "", fmt.Errorf("image %s is empty", path)
+	}
+	if width > bounds.Dx() {
+		width = bounds.Dx()
+	}
+
+	// Terminal cells are roughly twice as tall as they are wide
+	cellW := float64(bounds.Dx()) / float64(width)
+	cellH := cellW * 2
+	height := int(float64(bounds.Dy()) / cellH)
+	if height < 1 {
+		height = 1
+	}
+
+	var sb strings.Builder
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			x0 := bounds.Min.X + int(float64(col)*cellW)
+			y0 := bounds.Min.Y + int(float64(row)*cellH)
+			x1 := bounds.Min.X + int(float64(col+1)*cellW)
+			y1 := bounds.Min.Y + int(float64(row+1)*cellH)
+			sb.WriteByte(cellChar(img, x0, y0, max(x1, x0+1), min(max(y1, y0+1), bounds.Max.Y)))
+		}
+		if row < height-1 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String(), nil
+}
+
+// cellChar averages the pixels in [x0,x1)x[y0,y1) and maps their luminance to
+// the ramp, returning a space when every pixel is fully transparent
+func cellChar(img image.Image, x0, y0, x1, y1 int) byte {
+	var lum, alpha float64
+	count := 0
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			count++
+			if a == 0 {
+				continue
+			}
+			// RGBA values are alpha-premultiplied and 16-bit
+			lum += (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 0xFFFF
+			alpha += float64(a) / 0xFFFF
+		}
+	}
+	if count == 0 || alpha == 0 {
+		return ' '
+	}
+
+	// Un-premultiply so semi-transparent pixels keep their brightness
+	level := lum / alpha
+	idx := int(level * float64(len(luminanceRamp)-1))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(luminanceRamp) {
+		idx = len(luminanceRamp) - 1
+	}
+	return luminanceRamp[idx]
+}
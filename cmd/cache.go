@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ng-fetch/system"
+)
+
+// staticCacheTTL is the minimum lifetime of cached static fields (platform,
+// kernel, hostname, CPU model, ...), which rarely change between runs
+const staticCacheTTL = time.Hour
+
+// cacheVersion changes whenever the cached JSON layout does, so entries
+// written by older builds are ignored instead of decoding to empty fields
+const cacheVersion = 3
+
+// cacheEntry is the on-disk cache. Static and dynamic fields are tracked
+// separately so stale memory/disk/network figures can be refreshed without
+// re-running the slow static collectors.
+type cacheEntry struct {
+	Version   int                `json:"version"`
+	StaticAt  time.Time          `json:"static_at"`
+	DynamicAt time.Time          `json:"dynamic_at"`
+	Options   string             `json:"options"` // Options.CollectionKey of the run that wrote it
+	Info      *system.SystemInfo `json:"info"`
+}
+
+// cachePath returns the location of the cache file
+func cachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", "ng-fetch", "info.json"), nil
+}
+
+// collectInfo returns system information, served from the on-disk cache when
+// --cache-ttl is set and the cache is fresh enough
+func collectInfo(opts system.Options) (*system.SystemInfo, error) {
+	if cacheTTL <= 0 {
+		return system.CollectWithOptions(opts)
+	}
+
+	path, err := cachePath()
+	if err != nil {
+		return system.CollectWithOptions(opts)
+	}
+
+	now := time.Now()
+	staticTTL := max(cacheTTL, staticCacheTTL)
+	entry := readCache(path)
+
+	// An entry collected under other options, e.g. without --cpu-bars or
+	// --languages, lacks their fields and can't serve this run
+	key := opts.CollectionKey()
+	usable := entry != nil && entry.Options == key
+	dynamicFresh := usable && now.Sub(entry.DynamicAt) < cacheTTL
+	staticFresh := usable && now.Sub(entry.StaticAt) < staticTTL
+
+	switch {
+	case dynamicFresh:
+		return entry.Info, nil
+	case staticFresh:
+		info, err := system.RefreshDynamic(entry.Info, opts)
+		if err != nil {
+			return nil, err
+		}
+		entry = &cacheEntry{Version: cacheVersion, StaticAt: entry.StaticAt, DynamicAt: now, Options: key, Info: info}
+	default:
+		info, err := system.CollectWithOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		entry = &cacheEntry{Version: cacheVersion, StaticAt: now, DynamicAt: now, Options: key, Info: info}
+	}
+
+	// The cache is best-effort; failing to write it shouldn't fail the run
+	_ = writeCache(path, entry)
+	return entry.Info, nil
+}
+
+// readCache loads the cache file, returning nil when it is missing, corrupt
+// or written by a build with a different cache layout
+func readCache(path string) *cacheEntry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != cacheVersion || entry.Info == nil {
+		return nil
+	}
+	return &entry
+}
+
+func writeCache(path string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ng-fetch/system"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config mirrors ~/.config/ng-fetch/config.yaml
+type config struct {
+	Metrics []metricConfig `yaml:"metrics"`
+	Custom  []customConfig `yaml:"custom"`
+}
+
+// metricConfig is a single entry of the metrics list; metrics are shown in
+// the order they are listed and Enabled defaults to true when omitted
+type metricConfig struct {
+	Key     string `yaml:"key"`
+	Enabled *bool  `yaml:"enabled"`
+}
+
+// customConfig defines a metric whose value is the output of a shell
+// command, e.g. {name: "IP", command: "curl -s ifconfig.me", timeout: 3s}
+type customConfig struct {
+	Name    string        `yaml:"name"`
+	Command string        `yaml:"command"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// configPath returns the location of the user's config file
+func configPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "ng-fetch", "config.yaml"), nil
+}
+
+// themesDir returns the directory searched for user theme files
+func themesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "ng-fetch", "themes"), nil
+}
+
+// loadConfig reads the user's config file, returning nil when none exists
+func loadConfig() (*config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+
+	for _, m := range cfg.Metrics {
+		if !system.IsMetricKey(m.Key) {
+			return nil, fmt.Errorf("unknown metric %q in %s", m.Key, path)
+		}
+	}
+	for _, c := range cfg.Custom {
+		if c.Name == "" || c.Command == "" {
+			return nil, fmt.Errorf("custom metrics in %s need both a name and a command", path)
+		}
+	}
+	return &cfg, nil
+}
+
+// metricOrder returns the enabled metric keys in configured order, or nil
+// when the config doesn't list any metrics
+func (c *config) metricOrder() []string {
+	if c == nil || len(c.Metrics) == 0 {
+		return nil
+	}
+
+	order := make([]string, 0, len(c.Metrics))
+	for _, m := range c.Metrics {
+		if m.Enabled == nil || *m.Enabled {
+			order = append(order, m.Key)
+		}
+	}
+	return order
+}
+
+// customMetrics returns the custom metrics defined in the config
+func (c *config) customMetrics() []system.CustomMetric {
+	if c == nil {
+		return nil
+	}
+
+	metrics := make([]system.CustomMetric, 0, len(c.Custom))
+	for _, custom := range c.Custom {
+		metrics = append(metrics, system.CustomMetric{
+			Name:    custom.Name,
+			Command: custom.Command,
+			Timeout: custom.Timeout,
+		})
+	}
+	return metrics
+}
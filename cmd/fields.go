@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"ng-fetch/system"
+
+	"github.com/spf13/cobra"
+)
+
+var listFieldsCmd = &cobra.Command{
+	Use:   "list-fields",
+	Short: "List the metric keys accepted by --fields",
+	Run: func(cmd *cobra.Command, args []string) {
+		printFields()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listFieldsCmd)
+}
+
+// printFields lists every metric key in the default display order with its
+// description
+func printFields() {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, key := range system.MetricKeys() {
+		fmt.Fprintf(tw, "%s\t%s\n", key, system.MetricDescription(key))
+	}
+	tw.Flush()
+}
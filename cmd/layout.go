@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"ng-fetch/ascii"
+)
+
+// defaultArtInfoGap is the default number of spaces between the widest art
+// line and the info column
+const defaultArtInfoGap = 3
+
+// printSideBySide writes the art on the left and the info lines on the right,
+// gap spaces after the widest art line, continuing whichever column is
+// longer on its own
+func printSideBySide(w io.Writer, artLines, infoLines []string, gap int) {
+	artWidth := artWidth(artLines)
+
+	rows := len(artLines)
+	if len(infoLines) > rows {
+		rows = len(infoLines)
+	}
+
+	for i := 0; i < rows; i++ {
+		var art, info string
+		if i < len(artLines) {
+			art = artLines[i]
+		}
+		if i < len(infoLines) {
+			info = infoLines[i]
+		}
+
+		if info == "" {
+			fmt.Fprintln(w, art)
+			continue
+		}
+		// Reset colors from ANSI art so they don't bleed into the info column
+		if strings.Contains(art, "\x1b[") {
+			art += "\x1b[0m"
+		}
+		padding := strings.Repeat(" ", artWidth-ascii.Width(art)+gap)
+		fmt.Fprintf(w, "%s%s%s\n", art, padding, info)
+	}
+}
+
+// artWidth returns the width of the widest art line
+func artWidth(artLines []string) int {
+	width := 0
+	for _, line := range artLines {
+		if w := ascii.Width(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
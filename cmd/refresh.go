@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"ng-fetch/system"
+)
+
+// clearScreen clears the terminal and moves the cursor to the top left
+const clearScreen = "\033[2J\033[H"
+
+// runRefresh redraws the dashboard every refreshInterval until interrupted.
+// Only dynamic metrics are re-collected; static ones are reused from info.
+// Errors are returned as exitErrors so they're reported on stderr instead
+// of being drawn into the screen, and failed collectors of the last
+// refresh are reported like a single run's.
+func runRefresh(out io.Writer, info *system.SystemInfo, opts system.Options, artLines []string) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		fmt.Fprint(out, clearScreen)
+		if err := printDashboard(out, info, opts, artLines); err != nil {
+			return &exitError{1, err}
+		}
+
+		select {
+		case <-interrupt:
+			return collectionError(info, opts)
+		case <-ticker.C:
+		}
+
+		refreshed, err := system.RefreshDynamic(info, opts)
+		if err != nil {
+			return &exitError{exitFailed, err}
+		}
+		info = refreshed
+	}
+}
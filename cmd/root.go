@@ -1,50 +1,527 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"ng-fetch/ascii"
 	"ng-fetch/system"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
-	noAscii  bool
-	noColors bool
+	noAscii     bool
+	noColors    bool
+	jsonOut     bool
+	asciiFile   string
+	cpuUsage    bool
+	gradient    string
+	fields      string
+	imagePath   string
+	cacheTTL    time.Duration
+	width       int
+	outputPath  string
+	forceColors bool
+	plainOut    bool
+	minimalOut  bool
+	noIcons     bool
+	cpuBars     bool
+	netPerIface bool
+	iconSet     string
+	border      bool
+	languages   bool
+	asciiColor  string
+	asciiSize   string
+	verbose     bool
+	ipv6        bool
+	publicIP    bool
+	theme       string
+	align       bool
+	separator   string
+	diagnose    bool
+	diskIO      bool
+	bluetooth   bool
+	compact     bool
+	uptimeFmt   string
+	repeat      int
+	asciiDir    string
+	onlyASCII   bool
+	bars        bool
+	noNetwork   bool
+	sortBy      string
+	font        bool
+	gap         int
+	sshTarget   string
+	dump        bool
+	timings     bool
+	units       string
+
+	refreshInterval time.Duration
 )
 
+// imageArtWidth is the width in characters of art generated from --image
+const imageArtWidth = 40
+
 var rootCmd = &cobra.Command{
 	Use:   "neofetch-go",
 	Short: "A simple Neofetch clone written in Go",
-	Run: func(cmd *cobra.Command, args []string) {
-		runNeofetch()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Flags parsed fine, so errors from here on aren't usage mistakes
+		cmd.SilenceUsage = true
+		return runNeofetch()
 	},
+	SilenceErrors: true,
 }
 
+// Exit codes for collection failures, so scripts can tell a degraded run
+// from one that produced nothing. Other errors, like invalid flags, exit
+// with 1 as well.
+const (
+	exitPartial = 1 // some metrics couldn't be collected
+	exitFailed  = 2 // every core metric failed
+)
+
+// exitError is an error that carries the process exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			fmt.Fprintln(os.Stderr, exitErr)
+			os.Exit(exitErr.code)
+		}
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
+// collectionError returns an exitError describing the failed collectors of
+// info, or nil when every collector succeeded
+func collectionError(info *system.SystemInfo, opts system.Options) error {
+	failed := info.FailedMetrics()
+	switch {
+	case len(failed) == 0:
+		return nil
+	case info.CollectionFailed(opts):
+		return &exitError{exitFailed, fmt.Errorf("collection failed: %s (run with --diagnose for details)", strings.Join(failed, ", "))}
+	default:
+		return &exitError{exitPartial, fmt.Errorf("some metrics could not be collected: %s (run with --diagnose for details)", strings.Join(failed, ", "))}
+	}
+}
+
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&noAscii, "no-ascii", false, "Disable ASCII art display")
-	rootCmd.PersistentFlags().BoolVar(&noColors, "no-colors", false, "Disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&onlyASCII, "only-ascii", false, "Print only the ASCII art, without collecting system information")
+	rootCmd.PersistentFlags().BoolVar(&noColors, "no-colors", false, "Disable colored output (also disabled when NO_COLOR is set)")
+	rootCmd.PersistentFlags().StringVar(&asciiFile, "ascii-file", "", "Load ASCII art from the given file path, or from stdin with \"-\"")
+	rootCmd.PersistentFlags().StringVar(&asciiDir, "ascii-dir", "", "Search this directory for art before ~/.config/ng-fetch/art and the bundled art")
+	rootCmd.PersistentFlags().StringVar(&asciiColor, "ascii-color", "", "Tint the ASCII art with a color name or hex color, e.g. \"cyan\" or \"#ff8800\"")
+	rootCmd.PersistentFlags().StringVar(&asciiSize, "ascii-size", ascii.SizeLarge, "ASCII art size: small or large (small falls back to large when a logo has no small variant)")
+	rootCmd.PersistentFlags().IntVar(&gap, "gap", defaultArtInfoGap, "Number of spaces between the ASCII art and the info column")
+	rootCmd.PersistentFlags().StringVar(&imagePath, "image", "", "Render a PNG or JPEG image as ASCII art")
+	rootCmd.PersistentFlags().BoolVar(&cpuUsage, "cpu-usage", false, "Show current CPU usage (adds a one second sample)")
+	rootCmd.PersistentFlags().BoolVar(&cpuBars, "cpu-bars", false, "Show per-core CPU usage bars (adds a one second sample)")
+	rootCmd.PersistentFlags().IntVar(&repeat, "repeat", 1, "Average CPU usage, CPU bars and disk I/O over this many one second samples")
+	rootCmd.PersistentFlags().BoolVar(&diskIO, "disk-io", false, "Show disk read and write throughput (adds a one second sample)")
+	rootCmd.PersistentFlags().BoolVar(&font, "font", false, "Show the terminal font (iTerm2, gnome-terminal and Alacritty)")
+	rootCmd.PersistentFlags().BoolVar(&bluetooth, "bluetooth", false, "Show the number of connected Bluetooth devices (Linux and macOS)")
+	rootCmd.PersistentFlags().BoolVar(&ipv6, "ipv6", false, "Show the global IPv6 address")
+	rootCmd.PersistentFlags().BoolVar(&publicIP, "public-ip", false, "Show the public IP address (makes a request to api.ipify.org)")
+	rootCmd.PersistentFlags().BoolVar(&noNetwork, "no-network", false, "Skip collecting network traffic and hide the Network row")
+	rootCmd.PersistentFlags().StringVar(&sortBy, "sort", system.SortName, "Order of the disk and interface rows: name, usage or size")
+	rootCmd.PersistentFlags().BoolVar(&netPerIface, "net-per-iface", false, "Show one Network row per interface instead of the total")
+	rootCmd.PersistentFlags().StringVar(&theme, "theme", system.DefaultTheme, "Color theme: "+strings.Join(system.ThemeNames(), ", ")+", or a theme file in ~/.config/ng-fetch/themes")
+	rootCmd.PersistentFlags().StringVar(&gradient, "gradient", "", "Color metric labels with a 24-bit gradient, e.g. \"#ff5f6d,#ffc371\"")
+	rootCmd.PersistentFlags().StringVar(&fields, "fields", "", "Comma-separated list of metrics to show, e.g. \"cpu,memory\" (see list-fields)")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "Reuse collected info cached within this duration, e.g. 5s (0 disables the cache)")
+	rootCmd.PersistentFlags().IntVar(&width, "width", system.DefaultWidth, "Dashboard width in characters (0 auto-detects the terminal width)")
+	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Write the output to a file instead of stdout (disables colors)")
+	rootCmd.PersistentFlags().BoolVar(&forceColors, "force-colors", false, "Keep colored output even when writing to a file or when NO_COLOR is set")
+	rootCmd.PersistentFlags().BoolVar(&noIcons, "no-icons", false, "Disable Nerd Font icons (also disabled when NERD_FONT=0)")
+	rootCmd.PersistentFlags().BoolVar(&border, "border", false, "Wrap the dashboard in a box")
+	rootCmd.PersistentFlags().BoolVar(&languages, "languages", false, "Show installed programming languages (runs each toolchain's version command)")
+	rootCmd.PersistentFlags().StringVar(&uptimeFmt, "uptime-format", system.UptimeElapsed, "Uptime display: elapsed (time since boot) or boot (boot timestamp)")
+	rootCmd.PersistentFlags().StringVar(&units, "units", system.UnitsBinary, "Size units: binary (GiB, MiB) or decimal (GB, MB)")
+	rootCmd.PersistentFlags().BoolVar(&bars, "bars", false, "Draw usage bars after the memory and disk figures")
+	rootCmd.PersistentFlags().BoolVar(&compact, "compact", false, "Hide metrics that are N/A, Unknown or zero")
+	rootCmd.PersistentFlags().BoolVar(&align, "align", false, "Line up the metric values in one column")
+	rootCmd.PersistentFlags().StringVar(&separator, "separator", system.SeparatorColon, "Separator between labels and values: \":\" or \"=\"")
+	rootCmd.PersistentFlags().StringVar(&iconSet, "icon-set", system.DefaultIconSet, "Metric icons: nerd, emoji or none")
+	rootCmd.PersistentFlags().BoolVar(&plainOut, "plain", false, "Print each metric as \"key: value\" without icons, colors or padding")
+	rootCmd.PersistentFlags().BoolVar(&minimalOut, "minimal", false, "Print the selected metrics (default hostname, cpu, memory) on one pipe-separated line")
+	rootCmd.PersistentFlags().DurationVar(&refreshInterval, "refresh", 0, "Redraw the dashboard on this interval, e.g. 2s, until Ctrl-C")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show a detailed memory breakdown")
+	rootCmd.PersistentFlags().BoolVar(&diagnose, "diagnose", false, "Report on stderr which collectors succeeded or failed, without printing the dashboard")
+	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Print system information as JSON")
+	rootCmd.PersistentFlags().BoolVar(&timings, "timings", false, "Report on stderr how long collection took overall and per collector")
+	rootCmd.PersistentFlags().BoolVar(&dump, "dump", false, "Print every raw value gopsutil reports as JSON, for debugging hardware detection")
+	rootCmd.PersistentFlags().StringVar(&sshTarget, "ssh", "", "Collect from a remote Linux host over SSH instead, e.g. \"user@host\" (needs key-based auth)")
 }
 
-func runNeofetch() {
-	// Fetch ASCII art
+// runNeofetch collects and prints the system information. Failed
+// collectors are reported as an exitError after the output is written.
+func runNeofetch() error {
+	opts, err := systemOptions()
+	if err != nil {
+		return err
+	}
+
+	// Rendered output goes to --output when set, with colors disabled unless
+	// explicitly forced
+	out := io.Writer(os.Stdout)
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %v", err)
+		}
+		defer file.Close()
+		out = file
+
+		if !forceColors {
+			opts.NoColor = true
+		}
+	}
+	if forceColors && !noColors {
+		opts.NoColor = false
+		// fatih/color checks NO_COLOR itself whenever a color is created
+		os.Unsetenv("NO_COLOR")
+	}
+
+	// Set colors before loading art so ANSI codes in assets get stripped
+	color.NoColor = opts.NoColor
+
+	// Art-only mode never touches the collectors
+	if onlyASCII {
+		for _, line := range loadArtLines(ascii.DetectDistroArt()) {
+			fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+
+	// The raw dump bypasses the collectors and the dashboard entirely
+	if dump {
+		return system.PrintDump(out)
+	}
+
+	// Diagnosis always collects afresh, since cached results would hide
+	// collectors that fail now
+	if diagnose {
+		info, err := collectFresh(opts)
+		if err != nil {
+			return &exitError{exitFailed, err}
+		}
+		system.PrintDiagnosis(os.Stderr, info, opts)
+		return collectionError(info, opts)
+	}
+
+	stopSpinner := startSpinner("Collecting system information...")
+	began := time.Now()
+	var info *system.SystemInfo
+	if sshTarget != "" {
+		info, err = collectFresh(opts)
+	} else {
+		info, err = collectInfo(opts)
+	}
+	collectionTime := time.Since(began)
+	stopSpinner()
+	if err != nil {
+		return &exitError{exitFailed, err}
+	}
 
-	if !noAscii {
-		ascii.PrintASCIIArt("default") // Fetch ASCII art as a string
+	var artLines []string
+	if !noAscii && !jsonOut && !plainOut && !minimalOut {
+		artName := ascii.DetectDistroArt()
+		// The platform starts with the remote os-release ID, e.g. "debian 12"
+		if sshTarget != "" {
+			artName = ascii.DistroArt(strings.SplitN(info.Platform, " ", 2)[0])
+		}
+		artLines = loadArtLines(artName)
 	}
 
-	// Print the system info along with ASCII art
-	err := system.PrintSystemInfo(noColors)
+	// --width 0 fits the dashboard into whatever the art leaves of the terminal
+	if width == 0 {
+		opts.Width = terminalWidth()
+		if len(artLines) > 0 {
+			opts.Width -= artWidth(artLines) + gap
+		}
+	}
+
+	if refreshInterval > 0 {
+		return runRefresh(out, info, opts, artLines)
+	}
+
+	if err := printDashboard(out, info, opts, artLines); err != nil {
+		return err
+	}
+	// Timings go to stderr so they don't end up in piped or --output files
+	if timings {
+		system.PrintTimings(os.Stderr, info, collectionTime)
+	}
+	return collectionError(info, opts)
+}
+
+// collectFresh collects without the cache, from the --ssh host when set
+func collectFresh(opts system.Options) (*system.SystemInfo, error) {
+	if sshTarget != "" {
+		return system.CollectRemote(sshTarget, opts)
+	}
+	return system.CollectWithOptions(opts)
+}
+
+// printDashboard writes the collected info in the format selected by the
+// flags: JSON, plain text, or the dashboard with optional art
+func printDashboard(out io.Writer, info *system.SystemInfo, opts system.Options, artLines []string) error {
+	// JSON mode prints only the collected data, without ASCII art or decoration
+	if jsonOut {
+		printJSON(out, info)
+		return nil
+	}
+
+	// Plain mode is line-per-metric text meant for grep and awk
+	if plainOut {
+		system.PrintPlain(out, info, opts)
+		return nil
+	}
+
+	// Minimal mode is a single line for status bars
+	if minimalOut {
+		system.PrintMinimal(out, info, opts)
+		return nil
+	}
+
+	infoLines, err := system.Render(info, opts)
+	if err != nil {
+		return err
+	}
+
+	// Without art the info is printed on its own
+	if noAscii {
+		for _, line := range infoLines {
+			fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+
+	// Print the system info alongside the ASCII art
+	printSideBySide(out, artLines, infoLines, gap)
+	return nil
+}
+
+// systemOptions builds the collection and display options from the flags
+// and the config file
+func systemOptions() (system.Options, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading config (using defaults):", err)
+	}
+
+	// User themes become selectable with --theme; broken files are skipped
+	if dir, err := themesDir(); err == nil {
+		if err := system.LoadThemes(dir); err != nil {
+			fmt.Fprintln(os.Stderr, "Error loading themes (skipping them):", err)
+		}
+	}
+
+	selected, err := parseFields(fields)
+	if err != nil {
+		return system.Options{}, err
+	}
+
+	if iconSet != "none" && !system.IsIconSet(iconSet) {
+		return system.Options{}, fmt.Errorf("unknown icon set %q (valid icon sets: nerd, emoji, none)", iconSet)
+	}
+
+	if asciiSize != ascii.SizeSmall && asciiSize != ascii.SizeLarge {
+		return system.Options{}, fmt.Errorf("unknown ASCII art size %q (valid sizes: small, large)", asciiSize)
+	}
+
+	if units != system.UnitsBinary && units != system.UnitsDecimal {
+		return system.Options{}, fmt.Errorf("unknown units %q (valid units: binary, decimal)", units)
+	}
+
+	if sortBy != system.SortName && sortBy != system.SortUsage && sortBy != system.SortSize {
+		return system.Options{}, fmt.Errorf("unknown sort order %q (valid orders: name, usage, size)", sortBy)
+	}
+
+	if onlyASCII && noAscii {
+		return system.Options{}, fmt.Errorf("--only-ascii and --no-ascii can't be combined")
+	}
+
+	// --refresh re-collects dynamic metrics from the local system, and
+	// --dump only reads the local system
+	if sshTarget != "" && refreshInterval > 0 {
+		return system.Options{}, fmt.Errorf("--ssh and --refresh can't be combined")
+	}
+	if sshTarget != "" && dump {
+		return system.Options{}, fmt.Errorf("--ssh and --dump can't be combined")
+	}
+
+	if gap < 0 {
+		return system.Options{}, fmt.Errorf("--gap can't be negative, got %d", gap)
+	}
+
+	if repeat < 1 {
+		return system.Options{}, fmt.Errorf("--repeat must be at least 1, got %d", repeat)
+	}
+
+	if uptimeFmt != system.UptimeElapsed && uptimeFmt != system.UptimeBoot {
+		return system.Options{}, fmt.Errorf("unknown uptime format %q (valid formats: elapsed, boot)", uptimeFmt)
+	}
+
+	if separator != system.SeparatorColon && separator != system.SeparatorEquals {
+		return system.Options{}, fmt.Errorf("unknown separator %q (valid separators: \":\", \"=\")", separator)
+	}
+
+	return system.Options{
+		NoColor:      noColors || noColorRequested(),
+		CPUUsage:     cpuUsage,
+		CPUBars:      cpuBars,
+		Metrics:      cfg.metricOrder(),
+		Fields:       selected,
+		Theme:        theme,
+		NetPerIface:  netPerIface,
+		NoNetwork:    noNetwork,
+		Sort:         sortBy,
+		Repeat:       repeat,
+		DiskIO:       diskIO,
+		Bluetooth:    bluetooth,
+		Font:         font,
+		IPv6:         ipv6,
+		PublicIP:     publicIP,
+		Gradient:     gradient,
+		Width:        width,
+		IconSet:      iconSet,
+		Border:       border,
+		Languages:    languages,
+		Verbose:      verbose,
+		Bars:         bars,
+		Compact:      compact,
+		UptimeFormat: uptimeFmt,
+		Align:        align,
+		Separator:    separator,
+		Timings:      timings,
+		Units:        units,
+		Custom:       cfg.customMetrics(),
+		NoIcons:      noIcons || iconSet == "none" || (iconSet == "nerd" && !nerdFontAvailable()),
+	}, nil
+}
+
+// noColorRequested reports whether the NO_COLOR environment variable asks
+// for plain output. Following https://no-color.org, any non-empty value
+// counts.
+func noColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// nerdFontAvailable reports whether icons are likely to render. Terminals
+// can't report their font, so users without a Nerd Font can opt out by
+// setting NERD_FONT to 0, false or no.
+func nerdFontAvailable() bool {
+	switch strings.ToLower(os.Getenv("NERD_FONT")) {
+	case "0", "false", "no":
+		return false
+	}
+	return true
+}
+
+// terminalWidth returns the width of the terminal on stdout, or the default
+// dashboard width when it can't be determined. The two cells used by the
+// dashboard's outer margins are excluded.
+func terminalWidth() int {
+	w, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 2 {
+		return system.DefaultWidth
+	}
+	return w - 2
+}
+
+// parseFields splits the comma-separated --fields value into lowercase
+// metric keys, rejecting unknown names
+func parseFields(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var keys []string
+	for _, field := range strings.Split(value, ",") {
+		key := strings.ToLower(strings.TrimSpace(field))
+		if key == "" {
+			continue
+		}
+		if !system.IsMetricKey(key) {
+			return nil, fmt.Errorf("unknown field %q (valid fields: %s)", field, strings.Join(system.MetricKeys(), ", "))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// loadArtLines returns the art selected by the flags, or the named distro
+// art, falling back to the default art when --image or --ascii-file can't be
+// read
+func loadArtLines(distroArt string) []string {
+	// Copy rather than prepend to ascii.SearchDirs, which would grow on
+	// every redraw
+	dirs := ascii.SearchDirs
+	if asciiDir != "" {
+		dirs = append([]string{asciiDir}, ascii.SearchDirs...)
+	}
+
+	var art string
+	var err error
+	if imagePath != "" {
+		art, err = ascii.ImageToASCII(imagePath, imageArtWidth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error converting image %s: %v (using default art)\n", imagePath, err)
+			art, err = ascii.LoadASCIIArtSizeIn(dirs, "default", asciiSize)
+		}
+	} else if asciiFile != "" {
+		art, err = ascii.LoadASCIIArtFromPath(asciiFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading ASCII art from %s: %v (using default art)\n", asciiFile, err)
+			art, err = ascii.LoadASCIIArtSizeIn(dirs, "default", asciiSize)
+		}
+	} else {
+		art, err = ascii.LoadASCIIArtSizeIn(dirs, distroArt, asciiSize)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading ASCII art:", err)
+		return nil
+	}
+
+	// --ascii-color is ignored with --no-colors, since Colorize respects
+	// color.NoColor
+	if asciiColor != "" {
+		tint, err := ascii.ParseColor(asciiColor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing --ascii-color: %v (using the art's own colors)\n", err)
+		} else {
+			art = ascii.Colorize(art, tint)
+		}
+	}
+	return ascii.SplitLines(art)
+}
+
+func printJSON(w io.Writer, info *system.SystemInfo) {
+	data, err := json.MarshalIndent(info.Rounded(), "", "  ")
 	if err != nil {
+		fmt.Println("Error encoding JSON:", err)
 		return
 	}
+	fmt.Fprintln(w, string(data))
 }
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// spinnerFrames are drawn in turn while collection runs
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is the delay between frames. The first frame only appears
+// after one interval, so fast collections never show the spinner.
+const spinnerInterval = 100 * time.Millisecond
+
+// startSpinner draws a spinner on stderr until the returned stop function is
+// called, which also clears it. Nothing is drawn unless both stdout and
+// stderr are terminals, so piped or redirected output stays clean.
+func startSpinner(message string) (stop func()) {
+	if !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+
+		drawn := false
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				if drawn {
+					fmt.Fprint(os.Stderr, "\r\033[K")
+				}
+				return
+			case <-ticker.C:
+				fmt.Fprintf(os.Stderr, "\r%s %s", spinnerFrames[i%len(spinnerFrames)], message)
+				drawn = true
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// commit and date are injected at build time, e.g.
+//
+//	go build -ldflags "-X ng-fetch/cmd.commit=$(git rev-parse --short HEAD) -X ng-fetch/cmd.date=$(date -u +%Y-%m-%d)"
+var (
+	commit = ""
+	date   = ""
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(versionString())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.Version = versionString()
+	rootCmd.SetVersionTemplate("{{.Version}}\n")
+}
+
+// versionString describes the build: module version, commit, build date, Go
+// version and platform. The commit and date fall back to the VCS details
+// recorded by the Go toolchain when they weren't injected.
+func versionString() string {
+	version := "devel"
+	buildCommit, buildDate := commit, date
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Version != "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && buildCommit == "":
+				buildCommit = setting.Value
+				if len(buildCommit) > 12 {
+					buildCommit = buildCommit[:12]
+				}
+			case setting.Key == "vcs.time" && buildDate == "":
+				buildDate = setting.Value
+			}
+		}
+	}
+
+	if buildCommit == "" {
+		buildCommit = "unknown"
+	}
+	if buildDate == "" {
+		buildDate = "unknown"
+	}
+	return fmt.Sprintf("ng-fetch %s (commit %s, built %s) %s %s/%s",
+		version, buildCommit, buildDate, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
@@ -1,94 +1,522 @@
+// Package system collects system information and renders it as a dashboard.
+// Use Collect to gather the data without printing anything.
 package system
 
 import (
 	"fmt"
 	"github.com/fatih/color"
-	"github.com/shirou/gopsutil/cpu"
-	"github.com/shirou/gopsutil/disk"
-	"github.com/shirou/gopsutil/host"
-	"github.com/shirou/gopsutil/mem"
-	"github.com/shirou/gopsutil/net"
+	"github.com/mattn/go-runewidth"
+	"io"
+	"runtime"
+	"sort"
 	"strings"
-	"unicode/utf8"
+	"sync"
+	"time"
 )
 
-// SystemInfo holds all system information
+// DefaultWidth is the dashboard width used when Options.Width is 0
+const DefaultWidth = 58
+
+// Usage thresholds (in percent) above which memory and disk values change color
+const (
+	warnThreshold     = 70
+	criticalThreshold = 90
+)
+
+// SystemInfo holds all system information. Sizes are 1024-based: fields
+// whose JSON names end in _gb or _mb hold GiB (1<<30 bytes) and MiB (1<<20
+// bytes), the same figures the text output labels GiB and MiB.
 type SystemInfo struct {
-	Platform    string
-	Kernel      string
-	Hostname    string
-	CPU         string
-	Memory      float64
-	Disk        float64
-	Uptime      float64
-	NetworkSent float64
-	NetworkRecv float64
-}
-
-// PrintSystemInfo displays system information in an enhanced format
-func PrintSystemInfo(noColor bool) error {
-	// If noColor is true, disable color output
-	color.NoColor = noColor
-
-	// Collect system information
-	info, err := collectSystemInfo()
-	if err != nil {
-		return fmt.Errorf("failed to collect system information: %v", err)
-	}
+	Platform      string     `json:"platform"`            // Distribution or OS name and version, e.g. "ubuntu 24.04"
+	Kernel        string     `json:"kernel"`              // Kernel version
+	Arch          string     `json:"arch"`                // Architecture and bitness, e.g. "x86_64 (64-bit)"
+	WSL           string     `json:"wsl"`                 // "WSL1" or "WSL2" under WSL; empty otherwise
+	Hostname      string     `json:"hostname"`            // Network hostname
+	User          string     `json:"user"`                // Name of the user running ng-fetch
+	Init          string     `json:"init"`                // Init system, e.g. "systemd"; empty outside Linux
+	InContainer   bool       `json:"in_container"`        // Whether ng-fetch runs inside a container
+	Container     string     `json:"container"`           // Container runtime, e.g. "docker"; empty outside containers
+	Users         string     `json:"users"`               // Logged-in users with their count; empty when sessions can't be read
+	CPU           string     `json:"cpu"`                 // CPU model, core counts and clock speed when known
+	CPUUsage      float64    `json:"cpu_usage_percent"`   // CPU load in percent; only sampled when Options.CPUUsage is set
+	CPUTemp       float64    `json:"cpu_temp_celsius"`    // CPU temperature in °C; 0 when no sensor is available
+	CPUCores      []float64  `json:"cpu_cores_percent"`   // Per-core load in percent; only sampled when Options.CPUBars is set
+	GPU           string     `json:"gpu"`                 // Graphics cards joined by " / ", or "Unknown"
+	GPUStats      []GPUStat  `json:"gpu_stats"`           // Live NVIDIA figures from nvidia-smi; nil when it isn't installed
+	Model         string     `json:"model"`               // Machine model, e.g. "MacBookPro18,3"; empty when unavailable
+	Motherboard   string     `json:"motherboard"`         // Board vendor and model; empty when DMI data is missing
+	BIOS          string     `json:"bios"`                // BIOS vendor and version; empty when DMI data is missing
+	Shell         string     `json:"shell"`               // Shell name with version when known, e.g. "zsh 5.9"
+	Terminal      string     `json:"terminal"`            // Terminal emulator, or the TERM value when unidentified
+	Font          string     `json:"font"`                // Terminal font with Options.Font; "N/A" when it can't be read
+	Packages      string     `json:"packages"`            // Installed package counts per manager, e.g. "1423 (dpkg)"
+	DesktopEnv    string     `json:"desktop_env"`         // Desktop environment; empty when headless
+	WindowManager string     `json:"window_manager"`      // Window manager; empty when headless
+	SessionType   string     `json:"session_type"`        // "Wayland" or "X11" on Linux; empty when headless
+	Resolution    string     `json:"resolution"`          // Display resolutions joined by ", "; empty without a display
+	Memory        float64    `json:"memory_total_gb"`     // Total memory in GiB
+	MemoryUsed    float64    `json:"memory_used_gb"`      // Used memory in GiB
+	MemoryPercent float64    `json:"memory_used_percent"` // Used memory in percent
+	SwapUsed      float64    `json:"swap_used_gb"`        // Used swap in GiB
+	SwapTotal     float64    `json:"swap_total_gb"`       // Total swap in GiB; 0 when no swap is configured
+	Disk          float64    `json:"disk_total_gb"`       // Total size of the root filesystem (system drive on Windows) in GiB
+	DiskUsed      float64    `json:"disk_used_gb"`        // Used space on the root filesystem in GiB
+	DiskPercent   float64    `json:"disk_used_percent"`   // Root filesystem usage in percent
+	Disks         []DiskInfo `json:"disks"`               // Usage of every physical mountpoint
+	DiskReadMB    float64    `json:"disk_read_mb_s"`      // Disk read throughput in MiB/s, with Options.DiskIO
+	DiskWriteMB   float64    `json:"disk_write_mb_s"`     // Disk write throughput in MiB/s, with Options.DiskIO
+	Uptime        float64    `json:"uptime_hours"`        // Uptime in hours
+	BootTime      time.Time  `json:"boot_time"`           // When the system booted
+	NetworkSent   float64    `json:"network_sent_mb"`     // Data sent since boot in MiB
+	NetworkRecv   float64    `json:"network_recv_mb"`     // Data received since boot in MiB
+	NetInterfaces []NetIface `json:"net_interfaces"`      // Per-interface traffic, excluding loopback
+	LocalIP       string     `json:"local_ip"`            // IPv4 address of the primary interface; empty when offline
+	LocalIPv6     string     `json:"local_ipv6"`          // Global IPv6 address; only collected when Options.IPv6 is set
+	PublicIP      string     `json:"public_ip"`           // WAN address; only looked up when Options.PublicIP is set
+	LoadAvg       [3]float64 `json:"load_avg"`            // 1, 5 and 15 minute load averages; unset on Windows
+	ProcessCount  int        `json:"process_count"`       // Number of running processes; 0 when they can't be listed
+	LocalTime     time.Time  `json:"local_time"`          // Local time at collection
+	TimeZone      string     `json:"time_zone"`           // Local time zone abbreviation, e.g. "PDT"
+	Locale        string     `json:"locale"`              // Character locale, e.g. "en_US.UTF-8"; empty when unset
 
-	// Create color schemes
-	schemes := createColorSchemes()
+	// MemoryDetail breaks memory down further for Options.Verbose
+	MemoryDetail MemoryDetail `json:"memory_detail"`
 
-	// Print dashboard
-	//printDashboardHeader(schemes.header)
-	printSystemDetails(info, schemes)
-	//printLanguageSection(schemes)
+	// Languages lists the installed toolchains; only collected when
+	// Options.Languages is set
+	Languages []Language `json:"languages"`
 
-	return nil
+	// BluetoothDevices counts the connected Bluetooth devices; nil when
+	// Options.Bluetooth is unset or Bluetooth isn't available
+	BluetoothDevices *int `json:"bluetooth_devices"`
+
+	// Virtualization names the hypervisor, e.g. "KVM"; empty on bare metal
+	Virtualization string `json:"virtualization"`
+
+	// Custom holds the values of the user-defined metrics, in configured order
+	Custom []CustomValue `json:"custom"`
+
+	// Errors maps metric keys to the error of the collector that failed to
+	// fill them; those metrics render as "N/A"
+	Errors map[string]string `json:"errors"`
+
+	// Timings records how long each collector took, in completion order;
+	// only filled when Options.Timings is set
+	Timings []CollectorTiming `json:"-"`
 }
 
-func collectSystemInfo() (*SystemInfo, error) {
-	hostInfo, err := host.Info()
+// PrintSystemInfo writes system information to w in an enhanced format
+func PrintSystemInfo(w io.Writer, opts Options) error {
+	info, err := CollectWithOptions(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get host info: %v", err)
+		return err
 	}
 
-	cpuInfo, err := cpu.Info()
+	lines, err := Render(info, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get CPU info: %v", err)
+		return err
+	}
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
 	}
+	return nil
+}
 
-	cpuCount, err := cpu.Counts(true)
+// RenderSystemInfo collects system information and returns the rendered
+// dashboard lines instead of printing them
+func RenderSystemInfo(opts Options) ([]string, error) {
+	info, err := CollectWithOptions(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get CPU count: %v", err)
+		return nil, err
 	}
+	return Render(info, opts)
+}
+
+// Render returns the dashboard lines for already collected information
+func Render(info *SystemInfo, opts Options) ([]string, error) {
+	color.NoColor = opts.NoColor
 
-	memInfo, err := mem.VirtualMemory()
+	schemes, err := createColorSchemes(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get memory info: %v", err)
+		return nil, err
 	}
 
-	diskInfo, err := disk.Usage("/")
+	details, err := renderSystemDetails(info, schemes, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get disk info: %v", err)
+		return nil, err
 	}
 
-	netInfo, err := net.IOCounters(false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get network info: %v", err)
+	var lines []string
+	if opts.Border {
+		lines = boxLines(info, schemes, opts, details)
+	} else {
+		lines = append(headerLines(info, schemes), details...)
+		lines = append(lines, languageSectionLines(info, schemes, opts)...)
+	}
+	return append(lines, colorBlockLines(opts)...), nil
+}
+
+// Collect gathers system information with the default options, for use as a
+// library without the built-in dashboard
+func Collect() (*SystemInfo, error) {
+	return CollectWithOptions(Options{})
+}
+
+// CollectWithOptions gathers system information without printing it.
+// Failing collectors don't abort the collection: their errors are recorded
+// in info.Errors and the affected metrics render as "N/A".
+func CollectWithOptions(opts Options) (*SystemInfo, error) {
+	return CollectWithProvider(defaultProvider, opts)
+}
+
+// CollectWithProvider is like CollectWithOptions but reads the core metrics
+// from p instead of the live system
+func CollectWithProvider(p Provider, opts Options) (*SystemInfo, error) {
+	return collectSystemInfo(p, opts), nil
+}
+
+// RefreshDynamic returns a copy of prev with only the fields that change
+// while the system runs (uptime, memory, disk, network, ...) re-collected.
+// Static fields like Platform and CPU are reused as is.
+func RefreshDynamic(prev *SystemInfo, opts Options) (*SystemInfo, error) {
+	info := *prev
+	info.Disks = nil
+	info.Errors = make(map[string]string, len(prev.Errors))
+	for key, msg := range prev.Errors {
+		info.Errors[key] = msg
+	}
+	runCollectors(&info, defaultProvider, opts, false)
+	info.Custom = collectCustom(opts.Custom)
+	return &info, nil
+}
+
+// coreMetricKeys lists the metrics that make up a meaningful collection.
+// Their collectors record failures in SystemInfo.Errors, as does load's.
+var coreMetricKeys = []string{"platform", "kernel", "arch", "hostname", "cpu", "uptime", "memory", "disk", "network"}
+
+// FailedMetrics returns the sorted keys of the metrics whose collectors
+// failed
+func (info *SystemInfo) FailedMetrics() []string {
+	failed := make([]string, 0, len(info.Errors))
+	for key := range info.Errors {
+		failed = append(failed, key)
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// CollectionFailed reports whether every core collector that ran for opts
+// failed, leaving nothing meaningful to show. Core metrics that opts turned
+// off (like network with Options.NoNetwork) or hid with Options.Fields
+// don't count, and it's false when none are left.
+func (info *SystemInfo) CollectionFailed(opts Options) bool {
+	considered := 0
+	for _, key := range selectedKeys(opts) {
+		if !containsKey(coreMetricKeys, key) {
+			continue
+		}
+		if enabled := optInMetrics[key]; enabled != nil && !enabled(opts) {
+			continue
+		}
+		if info.Errors[key] == "" {
+			return false
+		}
+		considered++
+	}
+	return considered > 0
+}
+
+func collectSystemInfo(p Provider, opts Options) *SystemInfo {
+	info := &SystemInfo{}
+	runCollectors(info, p, opts, true)
+	// Custom metrics run last so a slow command doesn't compete with the
+	// built-in collectors
+	info.Custom = collectCustom(opts.Custom)
+	return info
+}
+
+// runCollectors fills info concurrently from p. Static collectors only run
+// when static is set; dynamic ones always run.
+func runCollectors(info *SystemInfo, p Provider, opts Options, static bool) {
+	// Every collector runs in its own goroutine and writes only its own
+	// fields, so only the error map and the timings need to be guarded
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	if info.Errors == nil {
+		info.Errors = make(map[string]string)
+	}
+	info.Timings = nil
+
+	// start runs collect in its own goroutine under name, recording its error
+	// against the metric keys it fills and clearing errors left by an
+	// earlier run
+	start := func(name string, keys []string, collect func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			began := time.Now()
+			err := collect()
+			elapsed := time.Since(began)
+			mu.Lock()
+			defer mu.Unlock()
+			if opts.Timings {
+				info.Timings = append(info.Timings, CollectorTiming{Name: name, Duration: elapsed})
+			}
+			for _, key := range keys {
+				if err != nil {
+					info.Errors[key] = err.Error()
+				} else {
+					delete(info.Errors, key)
+				}
+			}
+		}()
+	}
+	// track runs a core collector, named after the metrics it fills
+	track := func(keys []string, collect func() error) {
+		start(strings.Join(keys, "/"), keys, collect)
+	}
+	// run runs an independent collector whose failures aren't recorded
+	run := func(name string, collect func() error) {
+		start(name, nil, collect)
+	}
+
+	// Core collectors: a failure marks their metrics as unavailable
+	if static {
+		track([]string{"platform", "kernel", "arch", "hostname"}, func() error {
+			hostInfo, err := p.HostInfo()
+			if err != nil {
+				return fmt.Errorf("failed to get host info: %v", err)
+			}
+			info.Platform = fmt.Sprintf("%s %s", hostInfo.Platform, hostInfo.PlatformVersion)
+			// gopsutil reports "darwin" on macOS, so use the marketing name instead
+			if runtime.GOOS == "darwin" {
+				info.Platform = macOSPlatform(info.Platform)
+			}
+			info.Kernel = hostInfo.KernelVersion
+			// Under WSL the distribution runs on a Windows-provided kernel
+			if info.WSL = detectWSL(info.Kernel); info.WSL != "" {
+				info.Platform += " (on " + info.WSL + ")"
+			}
+			info.Arch = formatArch(hostInfo.KernelArch)
+			info.Hostname = hostInfo.Hostname
+			if hostInfo.BootTime > 0 {
+				info.BootTime = time.Unix(int64(hostInfo.BootTime), 0)
+			}
+			return nil
+		})
+
+		track([]string{"cpu"}, func() error {
+			cpuInfo, err := p.CPUInfo()
+			if err != nil {
+				return fmt.Errorf("failed to get CPU info: %v", err)
+			}
+			if len(cpuInfo) == 0 {
+				return fmt.Errorf("failed to get CPU info: no CPUs reported")
+			}
+
+			cpuCount, err := p.CPUCounts(true)
+			if err != nil {
+				return fmt.Errorf("failed to get CPU count: %v", err)
+			}
+			// Show both counts when SMT makes them differ. The physical count
+			// isn't available everywhere, so its failure isn't fatal.
+			cores := fmt.Sprintf("%d cores", cpuCount)
+			if physical, err := p.CPUCounts(false); err == nil && physical > 0 && physical != cpuCount {
+				cores = fmt.Sprintf("%d physical, %d logical", physical, cpuCount)
+			}
+			info.CPU = fmt.Sprintf("%s (%s)", cpuInfo[0].ModelName, cores)
+			if mhz := cpuFrequency(cpuInfo[0].Mhz); mhz > 0 {
+				info.CPU += fmt.Sprintf(" @ %.2f GHz", mhz/1000)
+			}
+			return nil
+		})
+	}
+
+	track([]string{"uptime"}, func() error {
+		uptime, err := p.Uptime()
+		if err != nil {
+			return fmt.Errorf("failed to get uptime: %v", err)
+		}
+		info.Uptime = float64(uptime) / 3600
+		return nil
+	})
+
+	track([]string{"memory"}, func() error {
+		memInfo, err := p.VirtualMemory()
+		if err != nil {
+			return fmt.Errorf("failed to get memory info: %v", err)
+		}
+		info.Memory = float64(memInfo.Total) / (1 << 30)
+		info.MemoryUsed = float64(memInfo.Used) / (1 << 30)
+		info.MemoryPercent = memInfo.UsedPercent
+		info.MemoryDetail = MemoryDetail{
+			Free:      float64(memInfo.Free) / (1 << 30),
+			Available: float64(memInfo.Available) / (1 << 30),
+			Cached:    float64(memInfo.Cached) / (1 << 30),
+			Buffers:   float64(memInfo.Buffers) / (1 << 30),
+		}
+		return nil
+	})
+
+	track([]string{"disk"}, func() error {
+		diskInfo, err := p.DiskUsage(rootPath())
+		if err != nil {
+			return fmt.Errorf("failed to get disk info: %v", err)
+		}
+		info.Disk = float64(diskInfo.Total) / (1 << 30)
+		info.DiskUsed = float64(diskInfo.Used) / (1 << 30)
+		info.DiskPercent = diskInfo.UsedPercent
+		return nil
+	})
+
+	if !opts.NoNetwork {
+		track([]string{"network"}, func() error {
+			ifaces, err := collectNetwork(p)
+			if err != nil {
+				return fmt.Errorf("failed to get network info: %v", err)
+			}
+			info.NetInterfaces = ifaces
+			info.NetworkSent, info.NetworkRecv = 0, 0
+			for _, iface := range ifaces {
+				info.NetworkSent += iface.Sent
+				info.NetworkRecv += iface.Recv
+			}
+			return nil
+		})
+	}
+
+	// Independent collectors: a failure just leaves their fields empty
+	// Sampled metrics are averaged over Options.Repeat samples
+	samples := sampleCount(opts)
+
+	if opts.CPUUsage {
+		run("cpu-usage", func() error {
+			percents, err := averageSamples(samples, func() ([]float64, error) {
+				return p.CPUPercent(time.Second, false)
+			})
+			if err == nil && len(percents) > 0 {
+				info.CPUUsage = percents[0]
+			}
+			return nil
+		})
+	}
+
+	if opts.CPUBars {
+		run("cpubars", func() error {
+			percents, err := averageSamples(samples, func() ([]float64, error) {
+				return p.CPUPercent(time.Second, true)
+			})
+			if err == nil {
+				info.CPUCores = percents
+			}
+			return nil
+		})
+	}
+
+	if opts.Bluetooth {
+		run("bluetooth", func() error {
+			info.BluetoothDevices = nil
+			if count, ok := detectBluetoothDevices(); ok {
+				info.BluetoothDevices = &count
+			}
+			return nil
+		})
+	}
+
+	if opts.DiskIO {
+		run("diskio", func() error {
+			rates, err := averageSamples(samples, func() ([]float64, error) {
+				read, write, err := collectDiskIO(p, time.Second)
+				return []float64{read, write}, err
+			})
+			if err == nil {
+				info.DiskReadMB, info.DiskWriteMB = rates[0], rates[1]
+			}
+			return nil
+		})
+	}
+
+	run("swap", func() error {
+		if swapInfo, err := p.SwapMemory(); err == nil {
+			info.SwapUsed = float64(swapInfo.Used) / (1 << 30)
+			info.SwapTotal = float64(swapInfo.Total) / (1 << 30)
+		}
+		return nil
+	})
+
+	run("temp", func() error { info.CPUTemp = detectCPUTemp(); return nil })
+	run("disks", func() error { info.Disks = collectDisks(); return nil })
+	run("localip", func() error { info.LocalIP = detectLocalIP(); return nil })
+	if opts.IPv6 {
+		run("localipv6", func() error { info.LocalIPv6 = detectLocalIPv6(); return nil })
 	}
+	run("users", func() error { info.Users = detectUsers(); return nil })
+	run("time", func() error {
+		info.LocalTime = time.Now()
+		info.TimeZone, _ = info.LocalTime.Zone()
+		return nil
+	})
+	run("gpu-stats", func() error { info.GPUStats = collectNvidiaGPUs(); return nil })
+
+	run("processes", func() error {
+		if pids, err := p.Pids(); err == nil {
+			info.ProcessCount = len(pids)
+		}
+		return nil
+	})
 
-	return &SystemInfo{
-		Platform:    fmt.Sprintf("%s %s", hostInfo.Platform, hostInfo.PlatformVersion),
-		Kernel:      hostInfo.KernelVersion,
-		Hostname:    hostInfo.Hostname,
-		CPU:         fmt.Sprintf("%s (%d cores)", cpuInfo[0].ModelName, cpuCount),
-		Memory:      float64(memInfo.Total) / (1 << 30),
-		Disk:        float64(diskInfo.Total) / (1 << 30),
-		Uptime:      float64(hostInfo.Uptime) / 3600,
-		NetworkSent: float64(netInfo[0].BytesSent) / (1 << 20),
-		NetworkRecv: float64(netInfo[0].BytesRecv) / (1 << 20),
-	}, nil
+	// Load average isn't a native concept on Windows
+	if runtime.GOOS != "windows" {
+		// A failure is recorded so the row shows N/A rather than zeros
+		start("load", []string{"load"}, func() error {
+			avg, err := p.LoadAvg()
+			if err != nil {
+				return fmt.Errorf("failed to get load average: %v", err)
+			}
+			info.LoadAvg = [3]float64{avg.Load1, avg.Load5, avg.Load15}
+			return nil
+		})
+	}
+
+	if static {
+		run("gpu", func() error { info.GPU = detectGPU(); return nil })
+		run("model", func() error { info.Model = detectModel(); return nil })
+		run("motherboard", func() error { info.Motherboard = detectMotherboard(); return nil })
+		run("bios", func() error { info.BIOS = detectBIOS(); return nil })
+		run("shell", func() error { info.Shell = detectShell(); return nil })
+		run("user", func() error { info.User = detectUser(); return nil })
+		if opts.PublicIP {
+			run("publicip", func() error { info.PublicIP = detectPublicIP(); return nil })
+		}
+		if opts.Languages {
+			run("languages", func() error { info.Languages = GetProgrammingLanguages(); return nil })
+		}
+		run("init", func() error { info.Init = detectInit(); return nil })
+		run("container", func() error {
+			info.Container = detectContainer()
+			info.InContainer = info.Container != ""
+			return nil
+		})
+		run("virt", func() error { info.Virtualization = detectVirtualization(); return nil })
+		run("locale", func() error { info.Locale = detectLocale(); return nil })
+		run("terminal", func() error {
+			info.Terminal = detectTerminal()
+			// The font lookup depends on which emulator was found
+			if opts.Font {
+				info.Font = detectFont(info.Terminal)
+			}
+			return nil
+		})
+		run("packages", func() error { info.Packages = detectPackages(); return nil })
+		run("de", func() error { info.DesktopEnv = detectDesktopEnv(); return nil })
+		run("wm", func() error { info.WindowManager = detectWindowManager(); return nil })
+		run("session", func() error { info.SessionType = detectSessionType(); return nil })
+		run("resolution", func() error { info.Resolution = detectResolution(); return nil })
+	}
+
+	wg.Wait()
 }
 
 type colorSchemes struct {
@@ -96,30 +524,100 @@ type colorSchemes struct {
 	section *color.Color
 	value   *color.Color
 	border  *color.Color
+	// gradient, when set, replaces header with per-line 24-bit colors
+	gradient *[2]rgb
+}
+
+func createColorSchemes(opts Options) (colorSchemes, error) {
+	schemes, err := lookupTheme(opts.Theme)
+	if err != nil {
+		return schemes, err
+	}
+
+	// Theme colors are created at startup and disable themselves when
+	// NO_COLOR is set, so re-enable them when colors are forced on
+	if !opts.NoColor {
+		for _, c := range []*color.Color{schemes.header, schemes.section, schemes.value, schemes.border} {
+			c.EnableColor()
+		}
+	}
+
+	if opts.Gradient != "" {
+		gradient, err := parseGradient(opts.Gradient)
+		if err != nil {
+			return schemes, fmt.Errorf("invalid gradient: %v", err)
+		}
+		// Keep the named colors on terminals without truecolor support
+		if supportsTrueColor() {
+			schemes.gradient = &gradient
+		}
+	}
+	return schemes, nil
 }
 
-func createColorSchemes() colorSchemes {
-	return colorSchemes{
-		header:  color.New(color.FgHiGreen, color.Bold),
-		section: color.New(color.FgHiBlue, color.Bold),
-		value:   color.New(color.FgWhite),
-		border:  color.New(color.FgHiBlack, color.Bold),
+// thresholdColor returns yellow or red for usage above the warning or
+// critical thresholds, and the fallback color otherwise
+func thresholdColor(percent float64, fallback *color.Color) *color.Color {
+	switch {
+	case percent > criticalThreshold:
+		return color.New(color.FgRed)
+	case percent > warnThreshold:
+		return color.New(color.FgYellow)
+	default:
+		return fallback
 	}
 }
 
-//
-//func printDashboardHeader(headerColor *color.Color) {
-//	timestamp := time.Now().Format("2006-01-02 15:04:05")
-//	borderLine := strings.Repeat("═", 60)
-//
-//	fmt.Printf("╔%s╗\n", borderLine)
-//	fmt.Printf("║ %s ║\n", centerText("SYSTEM INFORMATION DASHBOARD", 58))
-//	fmt.Printf("║ %s ║\n", centerText(timestamp, 58))
-//	fmt.Printf("╠%s╣\n", borderLine)
-//}
+// labelColor returns the label color for row i of n
+func (s colorSchemes) labelColor(i, n int) *color.Color {
+	if s.gradient != nil {
+		return gradientColor(*s.gradient, i, n)
+	}
+	return s.header
+}
 
+// boxLines wraps the dashboard lines in a double-line box with the
+// "user@hostname" title centered at the top and the languages section, if
+// any, below a divider. Every line is the dashboard width plus its two
+// margin spaces wide.
+func boxLines(info *SystemInfo, schemes colorSchemes, opts Options, details []string) []string {
+	totalWidth := dashboardWidth(opts)
+	borderLine := strings.Repeat("═", totalWidth+2)
+	divider := schemes.border.Sprintf("╠%s╣", borderLine)
+	titleLine := func(title string, c *color.Color) string {
+		return fmt.Sprintf("%s %s %s", schemes.border.Sprint("║"), c.Sprint(centerText(truncate(title, totalWidth), totalWidth)), schemes.border.Sprint("║"))
+	}
+	wrap := func(line string) string {
+		return schemes.border.Sprint("║") + line + schemes.border.Sprint("║")
+	}
+
+	lines := []string{schemes.border.Sprintf("╔%s╗", borderLine)}
+
+	title := info.User + "@" + info.Hostname
+	if info.User == "" || info.Hostname == "" {
+		title = info.User + info.Hostname
+	}
+	if title != "" {
+		lines = append(lines, titleLine(title, schemes.header), divider)
+	}
+
+	for _, line := range details {
+		lines = append(lines, wrap(line))
+	}
+
+	if rows := languageRows(info, opts); len(rows) > 0 {
+		lines = append(lines, divider, titleLine(languageSectionTitle, schemes.section), divider)
+		for _, line := range formatRows(rows, schemes, opts, totalWidth) {
+			lines = append(lines, wrap(line))
+		}
+	}
+	return append(lines, schemes.border.Sprintf("╚%s╝", borderLine))
+}
+
+// getDisplayWidth returns the number of terminal cells s occupies, counting
+// wide characters like emoji and CJK as two
 func getDisplayWidth(s string) int {
-	return utf8.RuneCountInString(s)
+	return runewidth.StringWidth(s)
 }
 
 func getPadding(content string, totalWidth int) string {
@@ -131,63 +629,111 @@ func getPadding(content string, totalWidth int) string {
 	return strings.Repeat(" ", paddingWidth)
 }
 
-func printSystemDetails(info *SystemInfo, schemes colorSchemes) {
-	const totalWidth = 58 // Total width of the display area
-
-	metrics := []struct {
-		icon  string
-		name  string
-		value interface{}
-		unit  string
-	}{
-		{"\uF17C", "Platform", info.Platform, ""},
-		{"\uE70F", "Kernel", info.Kernel, ""},
-		{"\uE795", "Hostname", info.Hostname, ""},
-		{"\uF4BC", "CPU", info.CPU, ""},
-		{"\uF85A", "Memory", info.Memory, "GB"},
-		{"\uF0A0", "Disk", info.Disk, "GB"},
-		{"\uF43A", "Uptime", info.Uptime, "hours"},
-		{"\uF6FF", "Network", fmt.Sprintf("↑%.2f MB | ↓%.2f MB", info.NetworkSent, info.NetworkRecv), ""},
-	}
-
-	for _, metric := range metrics {
-		var valueStr string
-		if v, ok := metric.value.(float64); ok {
-			valueStr = fmt.Sprintf("%.2f %s", v, metric.unit)
-		} else {
-			valueStr = fmt.Sprintf("%v", metric.value)
-		}
-
-		line := fmt.Sprintf("%s %s: %s",
-			metric.icon,
-			schemes.header.Sprint(metric.name),
-			schemes.value.Sprint(valueStr))
-
-		padding := getPadding(line, totalWidth)
-		fmt.Printf(" %s%s \n", line, padding)
-	}
-}
-
-//
-//func printLanguageSection(schemes colorSchemes) {
-//	borderLine := strings.Repeat("═", 60)
-//	fmt.Printf("╠%s╣\n", borderLine)
-//	fmt.Printf("║ %s ║\n", centerText("INSTALLED PROGRAMMING LANGUAGES", 58))
-//	fmt.Printf("╠%s╣\n", borderLine)
-//
-//	languages := GetProgrammingLanguages()
-//	for _, lang := range languages {
-//		line := fmt.Sprintf("%s %s: %s",
-//			lang.Icon,
-//			schemes.section.Sprint(lang.Name),
-//			schemes.value.Sprint(lang.Version))
-//
-//		padding := getPadding(line, 56) // 58 - 2 for the border spaces
-//		fmt.Printf("║ %s%s ║\n", line, padding)
-//	}
-//
-//	fmt.Printf("╚%s╝\n", borderLine)
-//}
+func renderSystemDetails(info *SystemInfo, schemes colorSchemes, opts Options) ([]string, error) {
+	totalWidth := dashboardWidth(opts) // Total width of the display area
+	if minimum := minWidth(opts); totalWidth < minimum {
+		return nil, fmt.Errorf("width %d is too narrow, the metric labels need at least %d", totalWidth, minimum)
+	}
+
+	// Rows without a value (e.g. DE/WM when headless) are omitted, and in
+	// compact mode so are N/A and all-zero rows
+	var metrics []metricRow
+	for _, metric := range orderedMetricRows(info, opts) {
+		if metric.value == "" || (opts.Compact && isMeaninglessValue(metric)) {
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return formatRows(metrics, schemes, opts, totalWidth), nil
+}
+
+// formatRows renders rows as padded "label: value" lines of totalWidth plus
+// a margin space on each side. With opts.Align the labels are padded so the
+// values start in the same column.
+func formatRows(metrics []metricRow, schemes colorSchemes, opts Options, totalWidth int) []string {
+	sep := separatorText(opts)
+
+	column := 0
+	if opts.Align {
+		for _, metric := range metrics {
+			if w := getDisplayWidth(iconPrefix(metric, opts) + metric.name); w > column {
+				column = w
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(metrics))
+	for i, metric := range metrics {
+		valueStr := formatValue(metric)
+		valueColor := schemes.value
+		if v, ok := usageOf(metric.value); ok && !opts.NoColor {
+			valueColor = thresholdColor(v.percent, schemes.value)
+		}
+
+		// Long values are truncated to the remaining width, and labels that
+		// embed data (like mountpoints) are shortened to leave room for one.
+		// Alignment is dropped for labels that don't fit either way.
+		prefix := iconPrefix(metric, opts)
+		name := metric.name
+		pad := ""
+		if opts.Align {
+			pad = strings.Repeat(" ", column-getDisplayWidth(prefix+name))
+		}
+		labelWidth := getDisplayWidth(prefix + name + pad + sep)
+		if labelWidth >= totalWidth {
+			pad = ""
+			labelWidth = getDisplayWidth(prefix + name + sep)
+		}
+		if labelWidth >= totalWidth {
+			name = truncate(name, getDisplayWidth(name)-(labelWidth-totalWidth)-1)
+			labelWidth = totalWidth - 1
+		}
+		fullValue := valueStr
+		valueStr = truncate(valueStr, totalWidth-labelWidth)
+
+		// Traffic and bar values color their parts, unless truncation cut them
+		coloredValue := valueColor.Sprint(valueStr)
+		if v, ok := metric.value.(interface{ colorize(*color.Color) string }); ok && !opts.NoColor && valueStr == fullValue {
+			coloredValue = v.colorize(valueColor)
+		}
+
+		line := fmt.Sprintf("%s%s%s%s%s",
+			prefix,
+			schemes.labelColor(i, len(metrics)).Sprint(name),
+			pad,
+			sep,
+			coloredValue)
+
+		// Pad based on the uncolored text so escape codes don't count as width
+		padding := getPadding(prefix+name+pad+sep+valueStr, totalWidth)
+		lines = append(lines, fmt.Sprintf(" %s%s ", line, padding))
+	}
+	return lines
+}
+
+// separatorText returns the text between a label and its value for the
+// configured separator
+func separatorText(opts Options) string {
+	if opts.Separator == SeparatorEquals {
+		return " = "
+	}
+	return ": "
+}
+
+// iconPrefix returns the row's icon followed by a space, or nothing when
+// icons are disabled
+func iconPrefix(metric metricRow, opts Options) string {
+	if opts.NoIcons {
+		return ""
+	}
+	return metric.icon + " "
+}
+
+// truncate shortens s to at most width cells, marking the cut with "…"
+func truncate(s string, width int) string {
+	return runewidth.Truncate(s, width, "…")
+}
 
 func centerText(text string, width int) string {
 	displayWidth := getDisplayWidth(text)
@@ -0,0 +1,67 @@
+package system
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// barValue is a usage value followed by a bar of width cells, e.g.
+// "5.00 GiB / 8.00 GiB (62%) [█████░░░]"
+type barValue struct {
+	usageValue
+	width int
+}
+
+func (v barValue) String() string {
+	return v.text + " " + barText(v.percent, v.width)
+}
+
+// colorize renders the value with the figures in c and the bar colored by
+// threshold
+func (v barValue) colorize(c *color.Color) string {
+	return c.Sprint(v.text+" ") + renderBar(v.percent, v.width)
+}
+
+// renderBar returns a usage bar of width cells like "[█████░░░]", green
+// below the warning threshold and yellow or red above it
+func renderBar(percent float64, width int) string {
+	return thresholdColor(percent, color.New(color.FgGreen)).Sprint(barText(percent, width))
+}
+
+// barText returns the uncolored bar drawn by renderBar
+func barText(percent float64, width int) string {
+	filled := int(percent/100*float64(width) + 0.5)
+	filled = min(max(filled, 0), width)
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// barWidth scales the bars with the dashboard, leaving room for the label
+// and figures in front of them
+func barWidth(opts Options) int {
+	return max(dashboardWidth(opts)/4, 5)
+}
+
+// withBars adds a usage bar to every usage row when Options.Bars is set
+func withBars(rows []metricRow, opts Options) []metricRow {
+	if !opts.Bars {
+		return rows
+	}
+	for i, row := range rows {
+		if usage, ok := row.value.(usageValue); ok {
+			rows[i].value = barValue{usage, barWidth(opts)}
+		}
+	}
+	return rows
+}
+
+// usageOf returns the usage behind a row's value, with or without a bar
+func usageOf(value interface{}) (usageValue, bool) {
+	switch v := value.(type) {
+	case usageValue:
+		return v, true
+	case barValue:
+		return v.usageValue, true
+	}
+	return usageValue{}, false
+}
@@ -0,0 +1,8 @@
+package system
+
+// detectBluetoothDevices returns the number of connected Bluetooth devices.
+// ok is false when the Bluetooth tools aren't installed or the platform
+// isn't supported.
+func detectBluetoothDevices() (count int, ok bool) {
+	return platformBluetooth()
+}
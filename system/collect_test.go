@@ -0,0 +1,283 @@
+package system
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+	psnet "github.com/shirou/gopsutil/net"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// fakeProvider serves canned figures. Methods named in failing return an
+// error instead, and every call sleeps for delay and is counted in calls.
+type fakeProvider struct {
+	failing map[string]bool
+	delay   time.Duration
+	calls   *atomic.Int64
+}
+
+func (f fakeProvider) call(method string) error {
+	if f.calls != nil {
+		f.calls.Add(1)
+	}
+	time.Sleep(f.delay)
+	if f.failing[method] {
+		return fmt.Errorf("%s: fake failure", method)
+	}
+	return nil
+}
+
+func (f fakeProvider) HostInfo() (*host.InfoStat, error) {
+	if err := f.call("HostInfo"); err != nil {
+		return nil, err
+	}
+	return &host.InfoStat{
+		Hostname:        "testbox",
+		Platform:        "debian",
+		PlatformVersion: "12.5",
+		KernelVersion:   "6.1.0-18-amd64",
+		KernelArch:      "x86_64",
+		BootTime:        1700000000,
+	}, nil
+}
+
+func (f fakeProvider) Uptime() (uint64, error) {
+	if err := f.call("Uptime"); err != nil {
+		return 0, err
+	}
+	return 3*86400 + 2*3600 + 15*60, nil
+}
+
+func (f fakeProvider) CPUInfo() ([]cpu.InfoStat, error) {
+	if err := f.call("CPUInfo"); err != nil {
+		return nil, err
+	}
+	return []cpu.InfoStat{{ModelName: "Fake CPU 9000", Mhz: 3600}}, nil
+}
+
+func (f fakeProvider) CPUCounts(logical bool) (int, error) {
+	if err := f.call("CPUCounts"); err != nil {
+		return 0, err
+	}
+	if logical {
+		return 16, nil
+	}
+	return 8, nil
+}
+
+func (f fakeProvider) CPUPercent(interval time.Duration, perCPU bool) ([]float64, error) {
+	if err := f.call("CPUPercent"); err != nil {
+		return nil, err
+	}
+	if perCPU {
+		return []float64{10, 20, 30, 40}, nil
+	}
+	return []float64{25}, nil
+}
+
+func (f fakeProvider) VirtualMemory() (*mem.VirtualMemoryStat, error) {
+	if err := f.call("VirtualMemory"); err != nil {
+		return nil, err
+	}
+	return &mem.VirtualMemoryStat{Total: 16 << 30, Used: 6 << 30, UsedPercent: 37.5, Free: 2 << 30, Available: 10 << 30}, nil
+}
+
+func (f fakeProvider) SwapMemory() (*mem.SwapMemoryStat, error) {
+	if err := f.call("SwapMemory"); err != nil {
+		return nil, err
+	}
+	return &mem.SwapMemoryStat{Total: 4 << 30, Used: 1 << 30}, nil
+}
+
+func (f fakeProvider) DiskUsage(path string) (*disk.UsageStat, error) {
+	if err := f.call("DiskUsage"); err != nil {
+		return nil, err
+	}
+	return &disk.UsageStat{Path: path, Total: 500 << 30, Used: 460 << 30, UsedPercent: 92}, nil
+}
+
+func (f fakeProvider) DiskIOCounters() (map[string]disk.IOCountersStat, error) {
+	if err := f.call("DiskIOCounters"); err != nil {
+		return nil, err
+	}
+	return map[string]disk.IOCountersStat{"sda": {ReadBytes: 1 << 20, WriteBytes: 2 << 20}}, nil
+}
+
+func (f fakeProvider) NetIOCounters(perNIC bool) ([]psnet.IOCountersStat, error) {
+	if err := f.call("NetIOCounters"); err != nil {
+		return nil, err
+	}
+	return []psnet.IOCountersStat{
+		{Name: "eth0", BytesSent: 100 << 20, BytesRecv: 300 << 20},
+		{Name: "wlan0", BytesSent: 5 << 20, BytesRecv: 10 << 20},
+	}, nil
+}
+
+func (f fakeProvider) LoadAvg() (*load.AvgStat, error) {
+	if err := f.call("LoadAvg"); err != nil {
+		return nil, err
+	}
+	return &load.AvgStat{Load1: 0.5, Load5: 0.75, Load15: 1}, nil
+}
+
+func (f fakeProvider) Pids() ([]int32, error) {
+	if err := f.call("Pids"); err != nil {
+		return nil, err
+	}
+	return make([]int32, 321), nil
+}
+
+// providerFields are the metrics backed only by the provider, so their rows
+// don't depend on the machine running the tests
+var providerFields = []string{
+	"platform", "kernel", "arch", "hostname", "cpu", "memory", "swap",
+	"disk", "uptime", "load", "processes", "network",
+}
+
+// allFailing makes every provider method fail
+var allFailing = map[string]bool{
+	"HostInfo": true, "Uptime": true, "CPUInfo": true, "CPUCounts": true,
+	"CPUPercent": true, "VirtualMemory": true, "SwapMemory": true, "DiskUsage": true,
+	"DiskIOCounters": true, "NetIOCounters": true, "LoadAvg": true, "Pids": true,
+}
+
+func TestCollectGolden(t *testing.T) {
+	// Platform names and load averages differ on macOS and Windows
+	if runtime.GOOS != "linux" {
+		t.Skip("golden files are recorded on Linux")
+	}
+	noColor := color.NoColor
+	t.Cleanup(func() { color.NoColor = noColor })
+	color.NoColor = true
+
+	tests := []struct {
+		name       string
+		failing    map[string]bool
+		opts       Options
+		wantErrors []string
+		wantFailed bool
+	}{
+		{name: "healthy"},
+		{name: "verbose_bars", opts: Options{Verbose: true, Bars: true, CPUUsage: true}},
+		{
+			name:       "host_failure",
+			failing:    map[string]bool{"HostInfo": true},
+			wantErrors: []string{"arch", "hostname", "kernel", "platform"},
+		},
+		{
+			name:       "memory_and_disk_failure",
+			failing:    map[string]bool{"VirtualMemory": true, "DiskUsage": true, "SwapMemory": true},
+			wantErrors: []string{"disk", "memory"},
+		},
+		{
+			name:       "all_failing",
+			failing:    allFailing,
+			wantErrors: []string{"arch", "cpu", "disk", "hostname", "kernel", "load", "memory", "network", "platform", "uptime"},
+			wantFailed: true,
+		},
+		{
+			// Network isn't collected, so the remaining core failures are total
+			name:       "all_failing_no_network",
+			failing:    allFailing,
+			opts:       Options{NoNetwork: true},
+			wantErrors: []string{"arch", "cpu", "disk", "hostname", "kernel", "load", "memory", "platform", "uptime"},
+			wantFailed: true,
+		},
+		{
+			// Compact mode drops the N/A rows
+			name:       "host_failure_compact",
+			failing:    map[string]bool{"HostInfo": true, "LoadAvg": true},
+			opts:       Options{Compact: true},
+			wantErrors: []string{"arch", "hostname", "kernel", "load", "platform"},
+		},
+		{
+			name:       "cpu_failure_decimal",
+			failing:    map[string]bool{"CPUInfo": true},
+			opts:       Options{Units: UnitsDecimal, Separator: SeparatorEquals, Align: true},
+			wantErrors: []string{"cpu"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.NoColor = true
+			opts.NoIcons = true
+			opts.Width = 60
+			opts.Fields = providerFields
+
+			info := collectSystemInfo(fakeProvider{failing: tt.failing}, opts)
+			// Per-mount disks are read from the live system, not the provider
+			info.Disks = nil
+
+			if got := info.FailedMetrics(); !slices.Equal(got, tt.wantErrors) {
+				t.Errorf("FailedMetrics() = %v, want %v", got, tt.wantErrors)
+			}
+			if got := info.CollectionFailed(opts); got != tt.wantFailed {
+				t.Errorf("CollectionFailed() = %v, want %v", got, tt.wantFailed)
+			}
+
+			schemes, err := createColorSchemes(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines, err := renderSystemDetails(info, schemes, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := strings.Join(lines, "\n") + "\n"
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run go test ./system -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("rows differ from %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+// BenchmarkCollect measures a full collection against a provider whose every
+// call takes delay. serial-ms/op is what the same calls would take one after
+// another, so it staying well above ns/op shows the collectors overlap.
+func BenchmarkCollect(b *testing.B) {
+	for _, delay := range []time.Duration{0, 5 * time.Millisecond, 20 * time.Millisecond} {
+		b.Run(delay.String(), func(b *testing.B) {
+			var calls atomic.Int64
+			p := fakeProvider{delay: delay, calls: &calls}
+			opts := Options{Fields: providerFields}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				collectSystemInfo(p, opts)
+			}
+			b.StopTimer()
+
+			perOp := float64(calls.Load()) / float64(b.N)
+			b.ReportMetric(perOp, "calls/op")
+			b.ReportMetric(perOp*float64(delay)/float64(time.Millisecond), "serial-ms/op")
+		})
+	}
+}
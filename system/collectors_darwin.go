@@ -0,0 +1,103 @@
+//go:build darwin
+
+package system
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// macOS platform collectors, which read system_profiler and sysctl
+
+// profilerResolution matches e.g. "Resolution: 2560 x 1600 Retina"
+var profilerResolution = regexp.MustCompile(`Resolution:\s*(\d+)\s*x\s*(\d+)`)
+
+// platformGPUs lists the chipset models reported by system_profiler
+func platformGPUs() ([]string, error) {
+	out, err := runCommand(context.Background(), "system_profiler", "SPDisplaysDataType")
+	if err != nil {
+		return nil, err
+	}
+
+	var gpus []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Chipset Model:") {
+			gpus = append(gpus, strings.TrimSpace(strings.TrimPrefix(line, "Chipset Model:")))
+		}
+	}
+	return gpus, nil
+}
+
+// platformResolutions lists the display resolutions reported by
+// system_profiler
+func platformResolutions() ([]string, error) {
+	out, err := runCommand(context.Background(), "system_profiler", "SPDisplaysDataType")
+	if err != nil {
+		return nil, err
+	}
+
+	var resolutions []string
+	for _, match := range profilerResolution.FindAllStringSubmatch(out, -1) {
+		resolutions = append(resolutions, match[1]+"x"+match[2])
+	}
+	return resolutions, nil
+}
+
+// platformModel returns the model identifier, e.g. "MacBookPro18,3"
+func platformModel() string {
+	out, err := runCommand(context.Background(), "sysctl", "-n", "hw.model")
+	if err != nil {
+		return timedOutValue(err, "")
+	}
+	return strings.TrimSpace(out)
+}
+
+// platformMotherboard returns "", since Macs don't expose board details
+func platformMotherboard() string {
+	return ""
+}
+
+// platformBIOS returns "", since Macs don't expose firmware details
+func platformBIOS() string {
+	return ""
+}
+
+// platformBluetooth counts the devices listed under the "Connected:" section
+// of system_profiler, or marked "Connected: Yes" on older macOS versions
+func platformBluetooth() (int, bool) {
+	out, err := runCommand(context.Background(), "system_profiler", "SPBluetoothDataType")
+	if err != nil {
+		return 0, false
+	}
+
+	count := 0
+	sectionIndent, deviceIndent := -1, -1
+	for _, line := range strings.Split(out, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		switch {
+		case trimmed == "Connected: Yes":
+			count++
+		case trimmed == "Connected:":
+			sectionIndent, deviceIndent = indent, -1
+		case sectionIndent >= 0 && indent <= sectionIndent:
+			sectionIndent = -1
+		case sectionIndent >= 0 && strings.HasSuffix(trimmed, ":"):
+			// Device names are the first level below the section; deeper
+			// lines are their properties
+			if deviceIndent < 0 {
+				deviceIndent = indent
+			}
+			if indent == deviceIndent {
+				count++
+			}
+		}
+	}
+	return count, true
+}
@@ -0,0 +1,107 @@
+//go:build linux
+
+package system
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Linux platform collectors: GPUs from lspci, resolutions from xrandr,
+// hardware from the kernel's DMI attributes and Bluetooth from bluetoothctl
+
+// dmiDir holds the DMI/SMBIOS attributes exposed by the Linux kernel
+const dmiDir = "/sys/devices/virtual/dmi/id"
+
+// xrandrMode matches the geometry of a connected output, e.g. "1920x1080+0+0"
+var xrandrMode = regexp.MustCompile(`(\d+x\d+)\+\d+\+\d+`)
+
+// platformGPUs lists the display controllers reported by lspci
+func platformGPUs() ([]string, error) {
+	out, err := runCommand(context.Background(), "lspci")
+	if err != nil {
+		return nil, err
+	}
+
+	var gpus []string
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, "VGA compatible controller") &&
+			!strings.Contains(line, "3D controller") &&
+			!strings.Contains(line, "Display controller") {
+			continue
+		}
+		// e.g. "01:00.0 VGA compatible controller: NVIDIA Corporation GA102 [GeForce RTX 3080]"
+		if idx := strings.Index(line, ": "); idx != -1 {
+			gpus = append(gpus, strings.TrimSpace(line[idx+2:]))
+		}
+	}
+	return gpus, nil
+}
+
+// platformResolutions lists the modes of the outputs xrandr reports as
+// connected
+func platformResolutions() ([]string, error) {
+	if !hasDisplay() {
+		return nil, nil
+	}
+
+	out, err := runCommand(context.Background(), "xrandr", "--current")
+	if err != nil {
+		return nil, err
+	}
+
+	var resolutions []string
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, " connected") {
+			continue
+		}
+		if match := xrandrMode.FindStringSubmatch(line); match != nil {
+			resolutions = append(resolutions, match[1])
+		}
+	}
+	return resolutions, nil
+}
+
+// platformModel returns the product name from DMI
+func platformModel() string {
+	return readDMI("product_name")
+}
+
+// platformMotherboard returns the board vendor and name from DMI
+func platformMotherboard() string {
+	return joinNonEmpty(readDMI("board_vendor"), readDMI("board_name"))
+}
+
+// platformBIOS returns the BIOS vendor and version from DMI
+func platformBIOS() string {
+	return joinNonEmpty(readDMI("bios_vendor"), readDMI("bios_version"))
+}
+
+// readDMI reads a single DMI attribute, ignoring placeholder values
+func readDMI(name string) string {
+	data, err := os.ReadFile(filepath.Join(dmiDir, name))
+	if err != nil {
+		return ""
+	}
+	return cleanDMI(string(data))
+}
+
+// platformBluetooth counts the "Device <address> <name>" lines printed by
+// bluetoothctl
+func platformBluetooth() (int, bool) {
+	out, err := runCommand(context.Background(), "bluetoothctl", "devices", "Connected")
+	if err != nil {
+		return 0, false
+	}
+
+	count := 0
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "Device ") {
+			count++
+		}
+	}
+	return count, true
+}
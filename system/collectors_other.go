@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !windows
+
+package system
+
+// Fallback platform collectors for operating systems without dedicated
+// support; every metric they back is left empty
+
+func platformGPUs() ([]string, error) { return nil, nil }
+
+func platformResolutions() ([]string, error) { return nil, nil }
+
+func platformModel() string { return "" }
+
+func platformMotherboard() string { return "" }
+
+func platformBIOS() string { return "" }
+
+func platformBluetooth() (int, bool) { return 0, false }
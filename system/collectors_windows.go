@@ -0,0 +1,99 @@
+//go:build windows
+
+package system
+
+import (
+	"context"
+	"strings"
+)
+
+// Windows platform collectors, which query WMI through wmic
+
+// platformGPUs lists the video controllers reported by WMI
+func platformGPUs() ([]string, error) {
+	out, err := runCommand(context.Background(), "wmic", "path", "win32_VideoController", "get", "name")
+	if err != nil {
+		return nil, err
+	}
+
+	var gpus []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		// Skip the "Name" column header and blank lines
+		if line == "" || line == "Name" {
+			continue
+		}
+		gpus = append(gpus, line)
+	}
+	return gpus, nil
+}
+
+// platformResolutions lists the current resolution of every active video
+// controller
+func platformResolutions() ([]string, error) {
+	out, err := runCommand(context.Background(), "wmic", "path", "Win32_VideoController", "get",
+		"CurrentHorizontalResolution,CurrentVerticalResolution")
+	if err != nil {
+		return nil, err
+	}
+
+	var resolutions []string
+	for _, line := range strings.Split(out, "\n") {
+		// Data lines look like "1920  1080"; the header and inactive
+		// controllers don't have two numeric columns
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !isDigits(fields[0]) || !isDigits(fields[1]) {
+			continue
+		}
+		resolutions = append(resolutions, fields[0]+"x"+fields[1])
+	}
+	return resolutions, nil
+}
+
+// platformModel returns the computer system model from WMI
+func platformModel() string {
+	return wmicValue("computersystem", "Model")
+}
+
+// platformMotherboard returns the baseboard vendor and product from WMI
+func platformMotherboard() string {
+	return joinNonEmpty(wmicValue("baseboard", "Manufacturer"), wmicValue("baseboard", "Product"))
+}
+
+// platformBIOS returns the BIOS vendor and version from WMI
+func platformBIOS() string {
+	return joinNonEmpty(wmicValue("bios", "Manufacturer"), wmicValue("bios", "SMBIOSBIOSVersion"))
+}
+
+// platformBluetooth reports Bluetooth as unavailable, since wmic has no
+// connected-device query
+func platformBluetooth() (int, bool) {
+	return 0, false
+}
+
+// wmicValue returns the first value of a WMI property via wmic
+func wmicValue(class, property string) string {
+	out, err := runCommand(context.Background(), "wmic", class, "get", property)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		// The first non-empty line is the column header
+		if line == "" || strings.EqualFold(line, property) {
+			continue
+		}
+		return cleanDMI(line)
+	}
+	return ""
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,33 @@
+package system
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// paletteRows are the background colors of the swatches: the 8 normal ANSI
+// colors on the first row and their bright variants on the second
+var paletteRows = [2][8]color.Attribute{
+	{color.BgBlack, color.BgRed, color.BgGreen, color.BgYellow, color.BgBlue, color.BgMagenta, color.BgCyan, color.BgWhite},
+	{color.BgHiBlack, color.BgHiRed, color.BgHiGreen, color.BgHiYellow, color.BgHiBlue, color.BgHiMagenta, color.BgHiCyan, color.BgHiWhite},
+}
+
+// colorBlockLines renders the terminal palette preview shown below the
+// dashboard, or nothing when colors are disabled
+func colorBlockLines(opts Options) []string {
+	if opts.NoColor {
+		return nil
+	}
+
+	lines := []string{""}
+	for _, row := range paletteRows {
+		var b strings.Builder
+		b.WriteString(" ")
+		for _, attr := range row {
+			b.WriteString(color.New(attr).Sprint("   "))
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
@@ -0,0 +1,63 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// commandTimeout bounds every external command, so a hung tool can't freeze
+// the whole run
+const commandTimeout = 2 * time.Second
+
+// errCommandTimeout is returned by runCommand for commands killed after
+// commandTimeout
+var errCommandTimeout = errors.New("command timed out")
+
+// runCommand runs name with args and returns its standard output. The
+// command is killed when ctx is done or after commandTimeout, whichever
+// comes first.
+func runCommand(ctx context.Context, name string, args ...string) (string, error) {
+	return execCommand(ctx, false, name, args...)
+}
+
+// runCombined is like runCommand but returns standard output and standard
+// error interleaved, for tools like "java -version" that report on stderr
+func runCombined(ctx context.Context, name string, args ...string) (string, error) {
+	return execCommand(ctx, true, name, args...)
+}
+
+// execCommand runs name under commandTimeout, capturing stdout alone or
+// combined with stderr
+func execCommand(ctx context.Context, combined bool, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	// Children that inherit stdout would otherwise keep Output waiting after
+	// the command itself was killed
+	cmd.WaitDelay = 100 * time.Millisecond
+
+	var out []byte
+	var err error
+	if combined {
+		out, err = cmd.CombinedOutput()
+	} else {
+		out, err = cmd.Output()
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%s: %w", name, errCommandTimeout)
+	}
+	return string(out), err
+}
+
+// timedOutValue returns "N/A" when err is a command timeout, so metrics whose
+// command hung show as unavailable, and fallback otherwise
+func timedOutValue(err error, fallback string) string {
+	if errors.Is(err, errCommandTimeout) {
+		return "N/A"
+	}
+	return fallback
+}
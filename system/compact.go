@@ -0,0 +1,49 @@
+package system
+
+import (
+	"strconv"
+	"strings"
+)
+
+// placeholderValues are values that stand for missing data
+var placeholderValues = map[string]bool{
+	"":        true,
+	"N/A":     true,
+	"Unknown": true,
+}
+
+// zeroValueUnits are the unit words that may follow a zero figure
+var zeroValueUnits = map[string]bool{
+	"B": true, "KB": true, "MB": true, "GB": true, "TB": true,
+	"KiB": true, "MiB": true, "GiB": true, "TiB": true,
+	"MB/s": true, "MiB/s": true, "read": true, "write": true, "/": true, "|": true,
+}
+
+// isMeaninglessValue reports whether a row shows no real data: an empty or
+// placeholder value, or one whose figures are all zero like "0.00 GiB / 0.00
+// GiB (0%)". Options.Compact drops these rows.
+func isMeaninglessValue(metric metricRow) bool {
+	text := formatValue(metric)
+	// Bars are decoration; only the figures in front of them count
+	if usage, ok := usageOf(metric.value); ok {
+		text = usage.text
+	}
+	text = strings.TrimSpace(text)
+	if placeholderValues[text] {
+		return true
+	}
+
+	sawZero := false
+	for _, word := range strings.Fields(text) {
+		word = strings.Trim(word, ",()%↑↓")
+		if zeroValueUnits[word] {
+			continue
+		}
+		if n, err := strconv.ParseFloat(word, 64); err == nil && n == 0 {
+			sawZero = true
+			continue
+		}
+		return false
+	}
+	return sawZero
+}
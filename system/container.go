@@ -0,0 +1,50 @@
+package system
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// cgroupRuntimes maps substrings of /proc/1/cgroup to container runtimes
+var cgroupRuntimes = []struct {
+	marker  string
+	runtime string
+}{
+	{"docker", "docker"},
+	{"libpod", "podman"},
+	{"kubepods", "kubernetes"},
+	{"lxc", "lxc"},
+	{"containerd", "containerd"},
+}
+
+// detectContainer returns the container runtime ng-fetch runs in, e.g.
+// "docker", or "" when it isn't running in a container
+func detectContainer() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+
+	if _, err := os.Stat("/.dockerenv"); err == nil {
+		return "docker"
+	}
+	if _, err := os.Stat("/run/.containerenv"); err == nil {
+		return "podman"
+	}
+	// Set by podman, lxc and systemd-nspawn among others
+	if name := os.Getenv("container"); name != "" {
+		return name
+	}
+
+	data, err := os.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return ""
+	}
+	cgroup := string(data)
+	for _, entry := range cgroupRuntimes {
+		if strings.Contains(cgroup, entry.marker) {
+			return entry.runtime
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package system
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// barLevels are the block characters used for per-core usage, from idle to
+// fully loaded
+var barLevels = []rune("▁▂▃▄▅▆▇█")
+
+// usageBar maps a usage percentage to a single block character
+func usageBar(percent float64) rune {
+	idx := int(math.Round(percent / 100 * float64(len(barLevels)-1)))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(barLevels) {
+		idx = len(barLevels) - 1
+	}
+	return barLevels[idx]
+}
+
+// cpuBarRows renders one bar per logical core, split across as many rows as
+// needed to stay within the dashboard width
+func cpuBarRows(info *SystemInfo, opts Options, icon string) []metricRow {
+	if !opts.CPUBars || len(info.CPUCores) == 0 {
+		return nil
+	}
+
+	// Size the rows for the widest label, e.g. "Cores 32-63"
+	widest := fmt.Sprintf("Cores %d-%d", len(info.CPUCores)-1, len(info.CPUCores)-1)
+	perRow := dashboardWidth(opts) - getDisplayWidth(fmt.Sprintf("%s%s: ", iconPrefix(metricRow{icon: icon}, opts), widest))
+	if perRow < 1 {
+		perRow = 1
+	}
+
+	var rows []metricRow
+	for start := 0; start < len(info.CPUCores); start += perRow {
+		end := min(start+perRow, len(info.CPUCores))
+
+		var bars strings.Builder
+		for _, percent := range info.CPUCores[start:end] {
+			bars.WriteRune(usageBar(percent))
+		}
+
+		name := "Cores"
+		if len(info.CPUCores) > perRow {
+			name = fmt.Sprintf("Cores %d-%d", start, end-1)
+		}
+		rows = append(rows, metricRow{icon, name, bars.String(), ""})
+	}
+	return rows
+}
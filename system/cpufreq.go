@@ -0,0 +1,53 @@
+package system
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// cpuFrequency returns the CPU clock in MHz, using the value reported by
+// gopsutil when it's set and falling back to /proc/cpuinfo and cpufreq on
+// Linux. It returns 0 when no frequency can be determined.
+func cpuFrequency(reported float64) float64 {
+	if reported > 0 {
+		return reported
+	}
+	if mhz := cpuinfoMHz(); mhz > 0 {
+		return mhz
+	}
+
+	// scaling_max_freq is in kHz
+	data, err := os.ReadFile("/sys/devices/system/cpu/cpu0/cpufreq/scaling_max_freq")
+	if err != nil {
+		return 0
+	}
+	khz, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	if err != nil {
+		return 0
+	}
+	return khz / 1000
+}
+
+// cpuinfoMHz returns the first "cpu MHz" value in /proc/cpuinfo
+func cpuinfoMHz() float64 {
+	file, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// e.g. "cpu MHz		: 3600.000"
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(key) != "cpu MHz" {
+			continue
+		}
+		if mhz, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return mhz
+		}
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package system
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCustomTimeout bounds custom metric commands without a timeout
+const defaultCustomTimeout = 2 * time.Second
+
+// CustomMetric is a user-defined metric whose value is a shell command's
+// output
+type CustomMetric struct {
+	Name    string
+	Command string
+	// Timeout bounds the command; 0 uses a two second default
+	Timeout time.Duration
+}
+
+// CustomValue is the collected value of a CustomMetric
+type CustomValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// collectCustom runs every custom metric command concurrently and returns
+// their values in the configured order. Commands that fail or time out
+// yield "N/A".
+func collectCustom(metrics []CustomMetric) []CustomValue {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	values := make([]CustomValue, len(metrics))
+	var wg sync.WaitGroup
+	for i, metric := range metrics {
+		wg.Add(1)
+		go func(i int, metric CustomMetric) {
+			defer wg.Done()
+			values[i] = CustomValue{Name: metric.Name, Value: runCustomCommand(metric)}
+		}(i, metric)
+	}
+	wg.Wait()
+	return values
+}
+
+// runCustomCommand runs the metric's command through the platform shell and
+// returns its trimmed output on a single line
+func runCustomCommand(metric CustomMetric) string {
+	timeout := metric.Timeout
+	if timeout <= 0 {
+		timeout = defaultCustomTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", metric.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", metric.Command)
+	}
+
+	// Killing the shell doesn't kill its children, which can keep stdout
+	// open, so stop waiting for output shortly after the timeout
+	cmd.WaitDelay = 100 * time.Millisecond
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "N/A"
+	}
+	return strings.Join(strings.Fields(string(out)), " ")
+}
+
+// customRows renders one row per custom metric
+func customRows(info *SystemInfo, icon string) []metricRow {
+	rows := make([]metricRow, 0, len(info.Custom))
+	for _, custom := range info.Custom {
+		rows = append(rows, metricRow{icon, custom.Name, custom.Value, ""})
+	}
+	return rows
+}
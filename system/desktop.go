@@ -0,0 +1,148 @@
+package system
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+// knownWindowManagers maps process names to display names for window
+// managers detected by process inspection
+var knownWindowManagers = map[string]string{
+	"sway":          "sway",
+	"hyprland":      "Hyprland",
+	"i3":            "i3",
+	"bspwm":         "bspwm",
+	"dwm":           "dwm",
+	"awesome":       "awesome",
+	"openbox":       "Openbox",
+	"fluxbox":       "Fluxbox",
+	"xmonad":        "xmonad",
+	"qtile":         "Qtile",
+	"herbstluftwm":  "herbstluftwm",
+	"icewm":         "IceWM",
+	"xfwm4":         "Xfwm4",
+	"kwin_x11":      "KWin",
+	"kwin_wayland":  "KWin",
+	"gnome-shell":   "Mutter",
+	"mutter":        "Mutter",
+	"marco":         "Marco",
+	"muffin":        "Muffin",
+	"weston":        "Weston",
+	"river":         "river",
+	"wayfire":       "Wayfire",
+	"enlightenment": "Enlightenment",
+}
+
+// hasDisplay reports whether a graphical session is available
+func hasDisplay() bool {
+	return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+// detectDesktopEnv returns the desktop environment name, or "" when running
+// headless
+func detectDesktopEnv() string {
+	if !hasDisplay() {
+		return ""
+	}
+
+	if desktop := os.Getenv("XDG_CURRENT_DESKTOP"); desktop != "" {
+		// Values like "ubuntu:GNOME" list the most specific desktop last
+		parts := strings.Split(desktop, ":")
+		return parts[len(parts)-1]
+	}
+	if session := os.Getenv("DESKTOP_SESSION"); session != "" {
+		return session
+	}
+	return "Unknown"
+}
+
+// detectWindowManager returns the window manager name, or "" when running
+// headless
+func detectWindowManager() string {
+	if !hasDisplay() {
+		return ""
+	}
+
+	if wm := windowManagerFromXprop(); wm != "" {
+		return wm
+	}
+	if wm := windowManagerFromProcesses(); wm != "" {
+		return wm
+	}
+	return "Unknown"
+}
+
+// detectSessionType returns "Wayland" or "X11" for Linux graphical
+// sessions, or "" on other platforms and when running headless
+func detectSessionType() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+
+	switch strings.ToLower(os.Getenv("XDG_SESSION_TYPE")) {
+	case "wayland":
+		return "Wayland"
+	case "x11":
+		return "X11"
+	}
+
+	// XWayland sets DISPLAY too, so WAYLAND_DISPLAY wins
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return "Wayland"
+	}
+	if os.Getenv("DISPLAY") != "" {
+		return "X11"
+	}
+	return ""
+}
+
+// windowManagerFromXprop reads _NET_WM_NAME from the window referenced by
+// the root window's _NET_SUPPORTING_WM_CHECK property
+func windowManagerFromXprop() string {
+	if os.Getenv("DISPLAY") == "" {
+		return ""
+	}
+
+	out, err := runCommand(context.Background(), "xprop", "-root", "-notype", "_NET_SUPPORTING_WM_CHECK")
+	if err != nil {
+		return ""
+	}
+	// e.g. "_NET_SUPPORTING_WM_CHECK: window id # 0x1e00008"
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return ""
+	}
+	windowID := fields[len(fields)-1]
+
+	out, err = runCommand(context.Background(), "xprop", "-id", windowID, "-notype", "_NET_WM_NAME")
+	if err != nil {
+		return ""
+	}
+	// e.g. `_NET_WM_NAME = "Mutter"`
+	if idx := strings.Index(out, "="); idx != -1 {
+		return strings.Trim(strings.TrimSpace(out[idx+1:]), `"`)
+	}
+	return ""
+}
+
+func windowManagerFromProcesses() string {
+	procs, err := process.Processes()
+	if err != nil {
+		return ""
+	}
+
+	for _, p := range procs {
+		name, err := p.Name()
+		if err != nil {
+			continue
+		}
+		if wm, ok := knownWindowManagers[strings.ToLower(name)]; ok {
+			return wm
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"fmt"
+	"io"
+)
+
+// optInMetrics maps metric keys that are only collected on request, or can
+// be turned off, to whether opts lets them run
+var optInMetrics = map[string]func(opts Options) bool{
+	"bluetooth": func(opts Options) bool { return opts.Bluetooth },
+	"cpubars":   func(opts Options) bool { return opts.CPUBars },
+	"diskio":    func(opts Options) bool { return opts.DiskIO },
+	"font":      func(opts Options) bool { return opts.Font },
+	"localipv6": func(opts Options) bool { return opts.IPv6 },
+	"network":   func(opts Options) bool { return !opts.NoNetwork },
+	"publicip":  func(opts Options) bool { return opts.PublicIP },
+	"custom":    func(opts Options) bool { return len(opts.Custom) > 0 },
+}
+
+// PrintDiagnosis writes the outcome of every metric's collector to w: "ok"
+// when it produced a value, "failed" with the error when it returned one,
+// "empty" when it found nothing (usually because the platform doesn't
+// support it) and "skipped" for metrics the options turned off.
+func PrintDiagnosis(w io.Writer, info *SystemInfo, opts Options) {
+	rows := metricRows(info, opts)
+	for _, key := range metricKeys {
+		status := "empty"
+		switch {
+		case info.Errors[key] != "":
+			status = "failed: " + info.Errors[key]
+		case optInMetrics[key] != nil && !optInMetrics[key](opts):
+			status = "skipped: not enabled"
+		default:
+			for _, row := range rows[key] {
+				if row.value != "" {
+					status = "ok"
+					break
+				}
+			}
+		}
+		fmt.Fprintf(w, "%-12s %s\n", key, status)
+	}
+}
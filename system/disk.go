@@ -0,0 +1,111 @@
+package system
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shirou/gopsutil/disk"
+)
+
+// DiskInfo holds usage information for a single mounted filesystem, in GiB
+type DiskInfo struct {
+	Mountpoint string  `json:"mountpoint"`
+	Used       float64 `json:"used_gb"`
+	Total      float64 `json:"total_gb"`
+}
+
+// pseudoFilesystems lists filesystem types that don't represent real storage
+var pseudoFilesystems = map[string]bool{
+	"tmpfs":       true,
+	"devtmpfs":    true,
+	"devfs":       true,
+	"proc":        true,
+	"sysfs":       true,
+	"overlay":     true,
+	"squashfs":    true,
+	"autofs":      true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"efivarfs":    true,
+	"ramfs":       true,
+	"nullfs":      true,
+	"fuse.portal": true,
+}
+
+// collectDisks returns usage for every physical mountpoint, skipping pseudo
+// filesystems and mounts whose usage can't be read
+func collectDisks() []DiskInfo {
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return nil
+	}
+
+	var disks []DiskInfo
+	seen := make(map[string]bool)
+	for _, partition := range partitions {
+		if pseudoFilesystems[partition.Fstype] || seen[partition.Mountpoint] {
+			continue
+		}
+		seen[partition.Mountpoint] = true
+
+		usage, err := disk.Usage(partition.Mountpoint)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+
+		disks = append(disks, DiskInfo{
+			Mountpoint: partition.Mountpoint,
+			Used:       float64(usage.Used) / (1 << 30),
+			Total:      float64(usage.Total) / (1 << 30),
+		})
+	}
+	return disks
+}
+
+// collectDiskIO samples the disk counters twice, interval apart, and returns
+// the read and write throughput in MB/s summed across devices
+func collectDiskIO(p Provider, interval time.Duration) (read, write float64, err error) {
+	before, err := p.DiskIOCounters()
+	if err != nil {
+		return 0, 0, err
+	}
+	time.Sleep(interval)
+	after, err := p.DiskIOCounters()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var readBytes, writeBytes uint64
+	for name, counters := range after {
+		prev, ok := before[name]
+		if !ok || isPartition(name, after) {
+			continue
+		}
+		// Counters can reset (e.g. a device was reattached), never go negative
+		if counters.ReadBytes >= prev.ReadBytes {
+			readBytes += counters.ReadBytes - prev.ReadBytes
+		}
+		if counters.WriteBytes >= prev.WriteBytes {
+			writeBytes += counters.WriteBytes - prev.WriteBytes
+		}
+	}
+
+	seconds := interval.Seconds()
+	return float64(readBytes) / (1 << 20) / seconds, float64(writeBytes) / (1 << 20) / seconds, nil
+}
+
+// isPartition reports whether name is a partition of another listed device,
+// like "sda1" of "sda" or "nvme0n1p1" of "nvme0n1", whose I/O the whole-disk
+// counters already include
+func isPartition(name string, devices map[string]disk.IOCountersStat) bool {
+	trimmed := strings.TrimRightFunc(name, unicode.IsDigit)
+	if trimmed == name {
+		return false
+	}
+	if _, ok := devices[trimmed]; ok {
+		return true
+	}
+	_, ok := devices[strings.TrimSuffix(trimmed, "p")]
+	return ok && strings.HasSuffix(trimmed, "p")
+}
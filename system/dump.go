@@ -0,0 +1,120 @@
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+	psnet "github.com/shirou/gopsutil/net"
+	"github.com/shirou/gopsutil/process"
+)
+
+// dumpEntry is one section of the raw dump: the value gopsutil returned, or
+// the error it failed with
+type dumpEntry struct {
+	Value any    `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// partitionDump pairs a partition with the usage read for its mountpoint
+type partitionDump struct {
+	Partition disk.PartitionStat `json:"partition"`
+	Usage     dumpEntry          `json:"usage"`
+}
+
+// rawDump holds every section of the dump, in output order
+type rawDump struct {
+	Host         dumpEntry `json:"host"`
+	Users        dumpEntry `json:"users"`
+	Temperatures dumpEntry `json:"temperatures"`
+	CPUInfo      dumpEntry `json:"cpu_info"`
+	CPUCounts    dumpEntry `json:"cpu_counts"`
+	CPUTimes     dumpEntry `json:"cpu_times"`
+	Memory       dumpEntry `json:"memory"`
+	Swap         dumpEntry `json:"swap"`
+	Partitions   dumpEntry `json:"partitions"`
+	DiskIO       dumpEntry `json:"disk_io"`
+	Interfaces   dumpEntry `json:"net_interfaces"`
+	NetIO        dumpEntry `json:"net_io"`
+	Load         dumpEntry `json:"load"`
+	Processes    dumpEntry `json:"process_count"`
+}
+
+// dumpValue runs one gopsutil query for the dump. A failing or panicking
+// query only marks its own section as failed.
+func dumpValue[T any](query func() (T, error)) (entry dumpEntry) {
+	defer func() {
+		if r := recover(); r != nil {
+			entry = dumpEntry{Error: fmt.Sprintf("panic: %v", r)}
+		}
+	}()
+
+	value, err := query()
+	if err != nil {
+		return dumpEntry{Value: value, Error: err.Error()}
+	}
+	return dumpEntry{Value: value}
+}
+
+// PrintDump writes every raw value gopsutil reports, across all CPUs,
+// partitions and interfaces, to w as indented JSON. It's meant for
+// debugging hardware detection, so sections that fail keep their error
+// instead of aborting the dump.
+func PrintDump(w io.Writer) error {
+	dump := rawDump{
+		Host:         dumpValue(host.Info),
+		Users:        dumpValue(host.Users),
+		Temperatures: dumpValue(host.SensorsTemperatures),
+		CPUInfo:      dumpValue(cpu.Info),
+		CPUCounts: dumpValue(func() (map[string]int, error) {
+			logical, err := cpu.Counts(true)
+			if err != nil {
+				return nil, err
+			}
+			physical, err := cpu.Counts(false)
+			return map[string]int{"logical": logical, "physical": physical}, err
+		}),
+		CPUTimes:   dumpValue(func() ([]cpu.TimesStat, error) { return cpu.Times(true) }),
+		Memory:     dumpValue(mem.VirtualMemory),
+		Swap:       dumpValue(mem.SwapMemory),
+		Partitions: dumpValue(dumpPartitions),
+		DiskIO:     dumpValue(func() (map[string]disk.IOCountersStat, error) { return disk.IOCounters() }),
+		Interfaces: dumpValue(psnet.Interfaces),
+		NetIO:      dumpValue(func() ([]psnet.IOCountersStat, error) { return psnet.IOCounters(true) }),
+		Load:       dumpValue(load.Avg),
+		Processes: dumpValue(func() (int, error) {
+			pids, err := process.Pids()
+			return len(pids), err
+		}),
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode dump: %v", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// dumpPartitions lists every partition, pseudo filesystems included, with
+// the usage of its mountpoint
+func dumpPartitions() ([]partitionDump, error) {
+	partitions, err := disk.Partitions(true)
+	if err != nil {
+		return nil, err
+	}
+
+	dumps := make([]partitionDump, 0, len(partitions))
+	for _, partition := range partitions {
+		dumps = append(dumps, partitionDump{
+			Partition: partition,
+			Usage:     dumpValue(func() (*disk.UsageStat, error) { return disk.Usage(partition.Mountpoint) }),
+		})
+	}
+	return dumps, nil
+}
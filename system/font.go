@@ -0,0 +1,97 @@
+package system
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// itermFont matches the font of an iTerm2 profile, e.g.
+	// `"Normal Font" = "MesloLGS-NF-Regular 13";`
+	itermFont = regexp.MustCompile(`"Normal Font"\s*=\s*"([^"]+)"`)
+	// alacrittyFamily matches the font family in alacritty.toml, e.g.
+	// `family = "JetBrains Mono"`, or in the older alacritty.yml, e.g.
+	// `family: JetBrains Mono`
+	alacrittyFamily = regexp.MustCompile(`(?m)^[^#\n]*\bfamily\s*[=:]\s*"?([^",}\n]+?)"?\s*(?:[,}]|$)`)
+)
+
+// detectFont returns the font of the given terminal emulator, or "N/A" when
+// the emulator isn't supported or its font can't be read
+func detectFont(terminal string) string {
+	var font string
+	switch terminal {
+	case "iTerm2":
+		font = fontFromITerm()
+	case "gnome-terminal":
+		font = fontFromGnomeTerminal()
+	case "Alacritty":
+		font = fontFromAlacritty()
+	}
+
+	if font == "" {
+		return "N/A"
+	}
+	return font
+}
+
+// fontFromITerm returns the font of the first iTerm2 profile
+func fontFromITerm() string {
+	out, err := runCommand(context.Background(), "defaults", "read", "com.googlecode.iterm2", "New Bookmarks")
+	if err != nil {
+		return ""
+	}
+	if match := itermFont.FindStringSubmatch(out); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// fontFromGnomeTerminal returns the font of the default gnome-terminal
+// profile, or the system monospace font when the profile uses it
+func fontFromGnomeTerminal() string {
+	if uuid := gsettingsValue("org.gnome.Terminal.ProfilesList", "default"); uuid != "" {
+		schema := "org.gnome.Terminal.Legacy.Profile:/org/gnome/terminal/legacy/profiles:/:" + uuid + "/"
+		if gsettingsValue(schema, "use-system-font") == "false" {
+			if font := gsettingsValue(schema, "font"); font != "" {
+				return font
+			}
+		}
+	}
+	return gsettingsValue("org.gnome.desktop.interface", "monospace-font-name")
+}
+
+// gsettingsValue returns a gsettings key with the quotes around strings
+// removed, or "" when it can't be read
+func gsettingsValue(schema, key string) string {
+	out, err := runCommand(context.Background(), "gsettings", "get", schema, key)
+	if err != nil {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(out), "'")
+}
+
+// fontFromAlacritty returns the font family from the Alacritty config file
+func fontFromAlacritty() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	for _, name := range []string{"alacritty.toml", "alacritty.yml"} {
+		data, err := os.ReadFile(filepath.Join(configDir, "alacritty", name))
+		if err != nil {
+			continue
+		}
+		if match := alacrittyFamily.FindStringSubmatch(string(data)); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// detectGPU returns the names of all detected graphics cards joined by " / ",
+// "N/A" when the detection command times out, or "Unknown" when detection
+// fails otherwise
+func detectGPU() string {
+	gpus, err := platformGPUs()
+	if len(gpus) == 0 {
+		return timedOutValue(err, "Unknown")
+	}
+	return strings.Join(gpus, " / ")
+}
+
+// GPUStat holds live memory and utilization figures for an NVIDIA card,
+// with memory in GiB
+type GPUStat struct {
+	Name        string  `json:"name"`
+	MemoryUsed  float64 `json:"memory_used_gb"`
+	MemoryTotal float64 `json:"memory_total_gb"`
+	Utilization float64 `json:"utilization_percent"`
+}
+
+// collectNvidiaGPUs queries nvidia-smi for every NVIDIA card. It returns nil
+// when nvidia-smi isn't installed or its output can't be parsed.
+func collectNvidiaGPUs() []GPUStat {
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return nil
+	}
+
+	out, err := runCommand(context.Background(), "nvidia-smi",
+		"--query-gpu=name,memory.used,memory.total,utilization.gpu",
+		"--format=csv,noheader,nounits")
+	if err != nil {
+		return nil
+	}
+
+	var gpus []GPUStat
+	for _, line := range strings.Split(out, "\n") {
+		// e.g. "NVIDIA GeForce RTX 3080, 2150, 10240, 45"
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			continue
+		}
+		used, errUsed := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		total, errTotal := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		util, errUtil := strconv.ParseFloat(strings.TrimSpace(fields[3]), 64)
+		if errUsed != nil || errTotal != nil || errUtil != nil {
+			continue
+		}
+		gpus = append(gpus, GPUStat{
+			Name:        strings.TrimSpace(fields[0]),
+			MemoryUsed:  used / 1024,
+			MemoryTotal: total / 1024,
+			Utilization: util,
+		})
+	}
+	return gpus
+}
+
+// gpuRows renders one row per NVIDIA card with live figures, falling back
+// to the plain name row when nvidia-smi isn't available
+func gpuRows(info *SystemInfo, opts Options, icon string) []metricRow {
+	if len(info.GPUStats) == 0 {
+		return []metricRow{{icon, "GPU", info.GPU, ""}}
+	}
+
+	units := unitsFor(opts)
+	rows := make([]metricRow, 0, len(info.GPUStats))
+	for _, gpu := range info.GPUStats {
+		rows = append(rows, metricRow{
+			icon,
+			"GPU",
+			usageValue{fmt.Sprintf("%s: %.1f/%.1f %s, %.0f%%", gpu.Name, gpu.MemoryUsed*units.gb, gpu.MemoryTotal*units.gb, units.gbLabel, gpu.Utilization), gpu.Utilization},
+			"",
+		})
+	}
+	return rows
+}
@@ -0,0 +1,69 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// rgb is a 24-bit color
+type rgb struct {
+	r, g, b int
+}
+
+// parseGradient parses a "start,end" pair of hex colors such as
+// "#ff0000,#0000ff"
+func parseGradient(spec string) ([2]rgb, error) {
+	parts := strings.Split(spec, ",")
+	if len(parts) != 2 {
+		return [2]rgb{}, fmt.Errorf("expected \"start,end\", got %q", spec)
+	}
+
+	start, err := parseHexColor(parts[0])
+	if err != nil {
+		return [2]rgb{}, err
+	}
+	end, err := parseHexColor(parts[1])
+	if err != nil {
+		return [2]rgb{}, err
+	}
+	return [2]rgb{start, end}, nil
+}
+
+// parseHexColor parses "#rrggbb" or "rrggbb"
+func parseHexColor(s string) (rgb, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) != 6 {
+		return rgb{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rgb{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	return rgb{int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)}, nil
+}
+
+// supportsTrueColor reports whether the terminal advertises 24-bit color
+func supportsTrueColor() bool {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	return colorTerm == "truecolor" || colorTerm == "24bit"
+}
+
+// gradientColor returns the color at position i of n evenly spaced steps
+// between the start and end colors
+func gradientColor(gradient [2]rgb, i, n int) *color.Color {
+	if n <= 1 {
+		return color.RGB(gradient[0].r, gradient[0].g, gradient[0].b).Add(color.Bold)
+	}
+
+	t := float64(i) / float64(n-1)
+	lerp := func(a, b int) int {
+		return a + int(float64(b-a)*t+0.5)
+	}
+	start, end := gradient[0], gradient[1]
+	return color.RGB(lerp(start.r, end.r), lerp(start.g, end.g), lerp(start.b, end.b)).Add(color.Bold)
+}
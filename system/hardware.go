@@ -0,0 +1,49 @@
+package system
+
+import "strings"
+
+// dmiPlaceholders are vendor filler strings that carry no information
+var dmiPlaceholders = map[string]bool{
+	"to be filled by o.e.m.": true,
+	"default string":         true,
+	"not applicable":         true,
+	"not specified":          true,
+	"system product name":    true,
+	"none":                   true,
+}
+
+// detectModel returns the machine model, e.g. "XPS 13 9310" or
+// "MacBookPro18,3", or "" when it can't be determined
+func detectModel() string {
+	return platformModel()
+}
+
+// detectMotherboard returns the board vendor and model, or "" when DMI data
+// is unavailable
+func detectMotherboard() string {
+	return platformMotherboard()
+}
+
+// detectBIOS returns the BIOS/firmware version, or "" when unavailable
+func detectBIOS() string {
+	return platformBIOS()
+}
+
+func cleanDMI(value string) string {
+	value = strings.TrimSpace(value)
+	if dmiPlaceholders[strings.ToLower(value)] {
+		return ""
+	}
+	return value
+}
+
+// joinNonEmpty joins the non-empty parts with spaces
+func joinNonEmpty(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " ")
+}
@@ -0,0 +1,38 @@
+package system
+
+import (
+	"os"
+	"os/user"
+	"strings"
+)
+
+// detectUser returns the current user name, without the domain on Windows
+func detectUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name := current.Username
+		if idx := strings.LastIndex(name, `\`); idx != -1 {
+			name = name[idx+1:]
+		}
+		return name
+	}
+	return os.Getenv("USER")
+}
+
+// headerLines renders the "user@hostname" title and a dashed separator of
+// the same length
+func headerLines(info *SystemInfo, schemes colorSchemes) []string {
+	title := schemes.header.Sprint(info.User) + schemes.border.Sprint("@") + schemes.header.Sprint(info.Hostname)
+	plain := info.User + "@" + info.Hostname
+	if info.User == "" || info.Hostname == "" {
+		plain = info.User + info.Hostname
+		title = schemes.header.Sprint(plain)
+	}
+	if plain == "" {
+		return nil
+	}
+
+	return []string{
+		" " + title,
+		" " + schemes.border.Sprint(strings.Repeat("-", getDisplayWidth(plain))),
+	}
+}
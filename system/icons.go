@@ -0,0 +1,96 @@
+package system
+
+// DefaultIconSet is the icon set used when none is selected
+const DefaultIconSet = "nerd"
+
+// iconSets maps each icon set name to the icon of every metric key. The
+// "nerd" glyphs need a Nerd Font; "emoji" works on most modern terminals.
+var iconSets = map[string]map[string]string{
+	"nerd": {
+		"platform":    "\uF17C",
+		"kernel":      "\uE70F",
+		"arch":        "\uF61A",
+		"wsl":         "\uF17A",
+		"hostname":    "\uE795",
+		"init":        "\uF013",
+		"container":   "\uF308",
+		"virt":        "\uF233",
+		"users":       "\uF0C0",
+		"cpu":         "\uF4BC",
+		"cpubars":     "\uF080",
+		"temp":        "\uF2C9",
+		"gpu":         "\uF878",
+		"host":        "\uF109",
+		"motherboard": "\uF2DB",
+		"bios":        "\uF0AD",
+		"shell":       "\uF489",
+		"terminal":    "\uF120",
+		"font":        "\uF031",
+		"packages":    "\uF487",
+		"de":          "\uF108",
+		"wm":          "\uF2D2",
+		"resolution":  "\uF26C",
+		"bluetooth":   "\uF293",
+		"memory":      "\uF85A",
+		"swap":        "\uF9E0",
+		"disk":        "\uF0A0",
+		"diskio":      "\uF0EC",
+		"uptime":      "\uF43A",
+		"time":        "\uF017",
+		"locale":      "\uF1AB",
+		"load":        "\uF0E4",
+		"processes":   "\uF0AE",
+		"network":     "\uF6FF",
+		"localip":     "\uF0AC",
+		"localipv6":   "\uF0AC",
+		"publicip":    "\uF0C2",
+		"custom":      "\uF121",
+	},
+	"emoji": {
+		"platform":    "💻",
+		"kernel":      "🐧",
+		"arch":        "🧩",
+		"wsl":         "🪟",
+		"hostname":    "🏠",
+		"init":        "🚀",
+		"container":   "🐳",
+		"virt":        "🧊",
+		"users":       "👥",
+		"cpu":         "🧠",
+		"cpubars":     "📊",
+		"temp":        "🔥",
+		"gpu":         "🎮",
+		"host":        "💼",
+		"motherboard": "🔌",
+		"bios":        "🔧",
+		"shell":       "🐚",
+		"terminal":    "📟",
+		"font":        "🔤",
+		"packages":    "📦",
+		"de":          "🎨",
+		"wm":          "🔲",
+		"resolution":  "📐",
+		"bluetooth":   "🎧",
+		"memory":      "🐏",
+		"swap":        "🔄",
+		"disk":        "💾",
+		"diskio":      "🔃",
+		"uptime":      "⏰",
+		"time":        "🕒",
+		"locale":      "💬",
+		"load":        "📈",
+		"processes":   "🧮",
+		"network":     "📡",
+		"localip":     "🌐",
+		"localipv6":   "🌐",
+		"publicip":    "🌍",
+		"custom":      "🔩",
+	},
+}
+
+// IsIconSet reports whether name is a known icon set. "none" is handled by
+// Options.NoIcons and isn't a set of its own.
+func IsIconSet(name string) bool {
+	_, ok := iconSets[name]
+	return ok
+}
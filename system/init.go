@@ -0,0 +1,47 @@
+package system
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// initNames maps PID 1 command names to init system names
+var initNames = map[string]string{
+	"systemd":   "systemd",
+	"runit":     "runit",
+	"s6-svscan": "s6",
+	"dinit":     "dinit",
+	"openrc":    "openrc",
+	"launchd":   "launchd",
+}
+
+// detectInit returns the name of the Linux init system, or an empty string
+// on other platforms and when PID 1 can't be inspected
+func detectInit() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+
+	if _, err := os.Stat("/run/systemd/system"); err == nil {
+		return "systemd"
+	}
+
+	data, err := os.ReadFile("/proc/1/comm")
+	if err != nil {
+		return ""
+	}
+	comm := strings.TrimSpace(string(data))
+	if name, ok := initNames[comm]; ok {
+		return name
+	}
+
+	// OpenRC and SysV both run /sbin/init as PID 1
+	if comm == "init" {
+		if _, err := os.Stat("/run/openrc"); err == nil {
+			return "openrc"
+		}
+		return "sysvinit"
+	}
+	return comm
+}
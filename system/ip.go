@@ -0,0 +1,86 @@
+package system
+
+import (
+	"net"
+)
+
+// detectLocalIP returns the IPv4 address of the interface used for the
+// default route, falling back to the first non-loopback IPv4 address
+func detectLocalIP() string {
+	if ip := defaultRouteIPv4(); ip != "" {
+		return ip
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ip := ipNet.IP.To4(); ip != nil && !ip.IsLoopback() {
+				return ip.String()
+			}
+		}
+	}
+	return ""
+}
+
+// defaultRouteIPv4 asks the OS which local address it would use to reach a
+// public address. Connecting a UDP socket sends no packets.
+func defaultRouteIPv4() string {
+	conn, err := net.Dial("udp4", "8.8.8.8:80")
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok || addr.IP.IsLoopback() {
+		return ""
+	}
+	return addr.IP.String()
+}
+
+// detectLocalIPv6 returns the first global unicast IPv6 address of an up,
+// non-loopback interface, or "" when IPv6 is disabled or unconfigured.
+// Link-local (fe80::) addresses are skipped.
+func detectLocalIPv6() string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.To4() != nil {
+				continue
+			}
+			if ipNet.IP.IsGlobalUnicast() && !ipNet.IP.IsLinkLocalUnicast() {
+				return ipNet.IP.String()
+			}
+		}
+	}
+	return ""
+}
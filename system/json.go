@@ -0,0 +1,43 @@
+package system
+
+import (
+	"math"
+	"reflect"
+)
+
+// Rounded returns a copy of the info with every float field rounded to two
+// decimals, suitable for JSON output
+func (info *SystemInfo) Rounded() *SystemInfo {
+	rounded := *info
+	roundFloats(reflect.ValueOf(&rounded).Elem())
+	return &rounded
+}
+
+func roundFloats(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Float64:
+		v.SetFloat(math.Round(v.Float()*100) / 100)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				roundFloats(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		// Nil slices stay nil so they still encode as null
+		if v.IsNil() {
+			return
+		}
+		// Copy the slice so rounding does not touch the original's backing array
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(copied, v)
+		v.Set(copied)
+		for i := 0; i < v.Len(); i++ {
+			roundFloats(v.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			roundFloats(v.Index(i))
+		}
+	}
+}
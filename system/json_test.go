@@ -0,0 +1,83 @@
+package system
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+var snakeCase = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+func TestJSONKeys(t *testing.T) {
+	devices := 2
+	info := &SystemInfo{
+		Platform:         "debian 12.5",
+		CPUCores:         []float64{12.345},
+		GPUStats:         []GPUStat{{Name: "RTX 4070", MemoryUsed: 1.5, MemoryTotal: 12}},
+		Disks:            []DiskInfo{{Mountpoint: "/", Used: 460, Total: 500}},
+		NetInterfaces:    []NetIface{{Name: "eth0", Sent: 105, Recv: 310}},
+		BootTime:         time.Unix(1700000000, 0).UTC(),
+		LocalTime:        time.Unix(1700003600, 0).UTC(),
+		Languages:        []Language{{Name: "Go", Version: "1.23.4"}},
+		BluetoothDevices: &devices,
+		Custom:           []CustomValue{{Name: "Weather", Value: "sunny"}},
+		Errors:           map[string]string{"disk": "failed to get disk info: fake failure"},
+	}
+
+	data, err := json.Marshal(info.Rounded())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"platform", "kernel", "arch", "wsl", "hostname", "user", "init",
+		"in_container", "container", "users", "cpu", "cpu_usage_percent",
+		"cpu_temp_celsius", "cpu_cores_percent", "gpu", "gpu_stats", "model",
+		"motherboard", "bios", "shell", "terminal", "font", "packages",
+		"desktop_env", "window_manager", "session_type", "resolution",
+		"memory_total_gb", "memory_used_gb", "memory_used_percent",
+		"swap_used_gb", "swap_total_gb", "disk_total_gb", "disk_used_gb",
+		"disk_used_percent", "disks", "disk_read_mb_s", "disk_write_mb_s",
+		"uptime_hours", "boot_time", "network_sent_mb", "network_recv_mb",
+		"net_interfaces", "local_ip", "local_ipv6", "public_ip", "load_avg",
+		"process_count", "local_time", "time_zone", "locale", "memory_detail",
+		"languages", "bluetooth_devices", "virtualization", "custom", "errors",
+	}
+	var got []string
+	for key := range decoded {
+		got = append(got, key)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("top-level keys = %v, want %v", got, want)
+	}
+
+	// The errors map is keyed by metric names, not by the schema
+	delete(decoded, "errors")
+	checkSnakeCase(t, "", decoded)
+}
+
+// checkSnakeCase reports every object key under value that isn't snake_case
+func checkSnakeCase(t *testing.T, path string, value any) {
+	t.Helper()
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if !snakeCase.MatchString(key) {
+				t.Errorf("key %q at %q is not snake_case", key, path)
+			}
+			checkSnakeCase(t, path+"."+key, child)
+		}
+	case []any:
+		for _, child := range v {
+			checkSnakeCase(t, path+"[]", child)
+		}
+	}
+}
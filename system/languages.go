@@ -0,0 +1,120 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sync"
+)
+
+// Language is an installed programming language toolchain
+type Language struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// languageCommand describes how to query a toolchain's version
+type languageCommand struct {
+	name    string
+	command []string
+}
+
+// languageCommands lists the detected toolchains in display order. Java
+// prints its version to stderr, which is read as well.
+var languageCommands = []languageCommand{
+	{"Go", []string{"go", "version"}},
+	{"Python", []string{"python3", "--version"}},
+	{"Node.js", []string{"node", "--version"}},
+	{"Rust", []string{"rustc", "--version"}},
+	{"Java", []string{"java", "-version"}},
+}
+
+// languageIcons maps icon set names to the icon of each language
+var languageIcons = map[string]map[string]string{
+	"nerd": {
+		"Go":      "\uE626",
+		"Python":  "\uE73C",
+		"Node.js": "\uE718",
+		"Rust":    "\uE7A8",
+		"Java":    "\uE738",
+	},
+	"emoji": {
+		"Go":      "🐹",
+		"Python":  "🐍",
+		"Node.js": "🟩",
+		"Rust":    "🦀",
+		"Java":    "☕",
+	},
+}
+
+// versionPattern matches the first dotted version number in command output
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// GetProgrammingLanguages returns the installed toolchains with their
+// versions, skipping those that aren't on the PATH
+func GetProgrammingLanguages() []Language {
+	found := make([]*Language, len(languageCommands))
+	var wg sync.WaitGroup
+	for i, lang := range languageCommands {
+		if _, err := exec.LookPath(lang.command[0]); err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, lang languageCommand) {
+			defer wg.Done()
+			if version := languageVersion(lang.command); version != "" {
+				found[i] = &Language{Name: lang.name, Version: version}
+			}
+		}(i, lang)
+	}
+	wg.Wait()
+
+	var languages []Language
+	for _, lang := range found {
+		if lang != nil {
+			languages = append(languages, *lang)
+		}
+	}
+	return languages
+}
+
+// languageVersion runs a version command and extracts the version number,
+// or returns "N/A" when the command hung
+func languageVersion(command []string) string {
+	out, err := runCombined(context.Background(), command[0], command[1:]...)
+	if err != nil {
+		return timedOutValue(err, "")
+	}
+	return versionPattern.FindString(string(out))
+}
+
+// languageRows renders one row per detected language
+func languageRows(info *SystemInfo, opts Options) []metricRow {
+	icons := languageIcons[opts.IconSet]
+	if icons == nil {
+		icons = languageIcons[DefaultIconSet]
+	}
+
+	rows := make([]metricRow, 0, len(info.Languages))
+	for _, lang := range info.Languages {
+		rows = append(rows, metricRow{icons[lang.Name], lang.Name, lang.Version, ""})
+	}
+	return rows
+}
+
+// languageSectionTitle heads the languages section
+const languageSectionTitle = "INSTALLED PROGRAMMING LANGUAGES"
+
+// languageSectionLines renders the languages section below the metrics, or
+// nothing when no languages were collected
+func languageSectionLines(info *SystemInfo, schemes colorSchemes, opts Options) []string {
+	rows := languageRows(info, opts)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	totalWidth := dashboardWidth(opts)
+	lines := []string{"", fmt.Sprintf(" %s ", schemes.section.Sprint(centerText(truncate(languageSectionTitle, totalWidth), totalWidth)))}
+	return append(lines, formatRows(rows, schemes, opts, totalWidth)...)
+}
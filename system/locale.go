@@ -0,0 +1,40 @@
+package system
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// localeVars lists the environment variables that set the character locale,
+// in the order POSIX gives them precedence
+var localeVars = []string{"LC_ALL", "LC_CTYPE", "LANG"}
+
+// detectLocale returns the current locale, e.g. "en_US.UTF-8", or "" when it
+// can't be determined
+func detectLocale() string {
+	for _, name := range localeVars {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	// The locale command also reports system defaults, e.g. from
+	// /etc/default/locale, as lines like `LC_CTYPE="en_US.UTF-8"`
+	out, err := runCommand(context.Background(), "locale")
+	if err != nil {
+		return ""
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), "="); ok {
+			values[key] = strings.Trim(value, `"`)
+		}
+	}
+	for _, name := range localeVars {
+		if values[name] != "" {
+			return values[name]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,417 @@
+package system
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+)
+
+// metricRow is a single labelled line of the dashboard
+type metricRow struct {
+	icon  string
+	name  string
+	value interface{}
+	unit  string
+}
+
+// usageValue is a metric value backed by a usage percentage, which drives
+// threshold coloring
+type usageValue struct {
+	text    string
+	percent float64
+}
+
+// metricKeys lists every metric key in the default display order
+var metricKeys = []string{
+	"platform",
+	"kernel",
+	"arch",
+	"wsl",
+	"hostname",
+	"init",
+	"container",
+	"virt",
+	"users",
+	"cpu",
+	"cpubars",
+	"temp",
+	"gpu",
+	"host",
+	"motherboard",
+	"bios",
+	"shell",
+	"terminal",
+	"font",
+	"packages",
+	"de",
+	"wm",
+	"resolution",
+	"bluetooth",
+	"memory",
+	"swap",
+	"disk",
+	"diskio",
+	"uptime",
+	"time",
+	"locale",
+	"load",
+	"processes",
+	"network",
+	"localip",
+	"localipv6",
+	"publicip",
+	"custom",
+}
+
+// metricDescriptions explains each metric key for --list-fields
+var metricDescriptions = map[string]string{
+	"platform":    "Operating system name and version",
+	"kernel":      "Kernel version",
+	"arch":        "Machine architecture and bitness",
+	"wsl":         "WSL version when running under the Windows Subsystem for Linux",
+	"hostname":    "Network hostname",
+	"init":        "Init system (Linux only)",
+	"container":   "Container runtime when running inside one",
+	"virt":        "Hypervisor when running in a virtual machine",
+	"users":       "Logged-in users",
+	"cpu":         "CPU model, core count and clock, plus load with --cpu-usage",
+	"cpubars":     "Per-core CPU usage bars (requires --cpu-bars)",
+	"temp":        "CPU temperature",
+	"gpu":         "Graphics cards, with memory and utilization on NVIDIA",
+	"host":        "Machine model",
+	"motherboard": "Motherboard vendor and model",
+	"bios":        "BIOS vendor and version",
+	"shell":       "Current shell and version",
+	"terminal":    "Terminal emulator",
+	"font":        "Terminal font for iTerm2, gnome-terminal and Alacritty (requires --font)",
+	"packages":    "Installed package count per package manager",
+	"de":          "Desktop environment",
+	"wm":          "Window manager and session type (Wayland or X11)",
+	"resolution":  "Display resolution",
+	"bluetooth":   "Connected Bluetooth devices on Linux and macOS (requires --bluetooth)",
+	"memory":      "Memory usage, broken down with --verbose and drawn as a bar with --bars",
+	"swap":        "Swap usage",
+	"disk":        "Disk usage per mountpoint, drawn as a bar with --bars",
+	"diskio":      "Disk read and write throughput (requires --disk-io)",
+	"uptime":      "Time since boot, or the boot time with --uptime-format boot",
+	"time":        "Current local time and time zone",
+	"locale":      "Character locale, e.g. en_US.UTF-8",
+	"load":        "1, 5 and 15 minute load averages",
+	"processes":   "Number of running processes",
+	"network":     "Data sent and received since boot, per interface with --net-per-iface",
+	"localip":     "IPv4 address of the primary interface",
+	"localipv6":   "Global IPv6 address (requires --ipv6)",
+	"publicip":    "Public IP address looked up online (requires --public-ip)",
+	"custom":      "Metrics defined by shell commands in the config file",
+}
+
+// MetricDescription returns a short description of the metric key
+func MetricDescription(key string) string {
+	return metricDescriptions[strings.ToLower(key)]
+}
+
+// MetricKeys returns every metric key in the default display order
+func MetricKeys() []string {
+	return append([]string(nil), metricKeys...)
+}
+
+// IsMetricKey reports whether key names a known metric (case-insensitive)
+func IsMetricKey(key string) bool {
+	return containsKey(metricKeys, strings.ToLower(key))
+}
+
+// dashboardWidth returns the configured dashboard width
+func dashboardWidth(opts Options) int {
+	if opts.Width == 0 {
+		return DefaultWidth
+	}
+	return opts.Width
+}
+
+// minWidth returns the narrowest dashboard width that fits every metric
+// label plus one character of value
+func minWidth(opts Options) int {
+	width := 0
+	for _, rows := range metricRows(&SystemInfo{}, Options{IconSet: opts.IconSet, Verbose: opts.Verbose}) {
+		for _, row := range rows {
+			if w := getDisplayWidth(iconPrefix(row, opts)+row.name+separatorText(opts)) + 1; w > width {
+				width = w
+			}
+		}
+	}
+	return width
+}
+
+// orderedMetricRows returns the dashboard rows in the configured order,
+// falling back to the default order when none is configured
+func orderedMetricRows(info *SystemInfo, opts Options) []metricRow {
+	rows := metricRows(info, opts)
+
+	var metrics []metricRow
+	for _, key := range selectedKeys(opts) {
+		metrics = append(metrics, rows[key]...)
+	}
+	return metrics
+}
+
+// selectedKeys returns the lowercase metric keys to display, in order
+func selectedKeys(opts Options) []string {
+	order := opts.Metrics
+	if order == nil {
+		order = metricKeys
+	}
+
+	var keys []string
+	for _, key := range order {
+		key = strings.ToLower(key)
+		if len(opts.Fields) > 0 && !containsKey(opts.Fields, key) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// formatValue renders a row's value as plain text
+func formatValue(metric metricRow) string {
+	switch v := metric.value.(type) {
+	case float64:
+		return fmt.Sprintf("%.2f %s", v, metric.unit)
+	case usageValue:
+		return v.text
+	case trafficValue:
+		return v.String()
+	case barValue:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", metric.value)
+	}
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if strings.ToLower(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// metricRows builds the rows for every metric, keyed by metric key
+func metricRows(info *SystemInfo, opts Options) map[string][]metricRow {
+	cpuValue := info.CPU
+	if opts.CPUUsage {
+		cpuValue = fmt.Sprintf("%s @ %.0f%%%s", info.CPU, info.CPUUsage, averageSuffix(opts))
+	}
+
+	units := unitsFor(opts)
+
+	// Machines without swap configured don't get a Swap row
+	var swapValue string
+	if info.SwapTotal > 0 {
+		swapValue = units.size(info.SwapUsed) + " / " + units.size(info.SwapTotal)
+	}
+
+	// VMs usually have no CPU sensor, so the row is omitted rather than showing 0°C
+	var tempValue string
+	if info.CPUTemp > 0 {
+		tempValue = fmt.Sprintf("%.1f°C", info.CPUTemp)
+	}
+
+	wmValue := info.WindowManager
+	if wmValue != "" && info.SessionType != "" {
+		wmValue = fmt.Sprintf("%s (%s)", wmValue, info.SessionType)
+	}
+
+	var timeValue string
+	if !info.LocalTime.IsZero() {
+		timeValue = info.LocalTime.Format("2006-01-02 15:04") + " " + info.TimeZone
+	}
+
+	var processValue string
+	if info.ProcessCount > 0 {
+		processValue = fmt.Sprintf("%d", info.ProcessCount)
+	}
+
+	var bluetoothValue string
+	if info.BluetoothDevices != nil {
+		bluetoothValue = fmt.Sprintf("%d connected", *info.BluetoothDevices)
+	}
+
+	var loadValue string
+	if runtime.GOOS != "windows" {
+		loadValue = fmt.Sprintf("%.2f, %.2f, %.2f", info.LoadAvg[0], info.LoadAvg[1], info.LoadAvg[2])
+	}
+
+	icons := iconSets[opts.IconSet]
+	if icons == nil {
+		icons = iconSets[DefaultIconSet]
+	}
+	// Theme files can override single icons on top of the set
+	if overrides := themeIcons[strings.ToLower(opts.Theme)]; len(overrides) > 0 {
+		merged := make(map[string]string, len(icons))
+		for key, icon := range icons {
+			merged[key] = icon
+		}
+		for key, icon := range overrides {
+			merged[key] = icon
+		}
+		icons = merged
+	}
+
+	rows := map[string][]metricRow{
+		"platform":    {{icons["platform"], "Platform", info.Platform, ""}},
+		"kernel":      {{icons["kernel"], "Kernel", info.Kernel, ""}},
+		"arch":        {{icons["arch"], "Arch", info.Arch, ""}},
+		"wsl":         {{icons["wsl"], "WSL", info.WSL, ""}},
+		"hostname":    {{icons["hostname"], "Hostname", info.Hostname, ""}},
+		"init":        {{icons["init"], "Init", info.Init, ""}},
+		"container":   {{icons["container"], "Container", info.Container, ""}},
+		"virt":        {{icons["virt"], "Virt", info.Virtualization, ""}},
+		"users":       {{icons["users"], "Users", info.Users, ""}},
+		"cpu":         {{icons["cpu"], "CPU", cpuValue, ""}},
+		"cpubars":     cpuBarRows(info, opts, icons["cpubars"]),
+		"temp":        {{icons["temp"], "CPU Temp", tempValue, ""}},
+		"gpu":         gpuRows(info, opts, icons["gpu"]),
+		"host":        {{icons["host"], "Host", info.Model, ""}},
+		"motherboard": {{icons["motherboard"], "Motherboard", info.Motherboard, ""}},
+		"bios":        {{icons["bios"], "BIOS", info.BIOS, ""}},
+		"shell":       {{icons["shell"], "Shell", info.Shell, ""}},
+		"terminal":    {{icons["terminal"], "Terminal", info.Terminal, ""}},
+		"font":        {{icons["font"], "Font", info.Font, ""}},
+		"packages":    {{icons["packages"], "Packages", info.Packages, ""}},
+		"de":          {{icons["de"], "DE", info.DesktopEnv, ""}},
+		"wm":          {{icons["wm"], "WM", wmValue, ""}},
+		"resolution":  {{icons["resolution"], "Resolution", info.Resolution, ""}},
+		"bluetooth":   {{icons["bluetooth"], "Bluetooth", bluetoothValue, ""}},
+		"memory":      withBars(memoryRows(info, opts, icons["memory"]), opts),
+		"swap":        {{icons["swap"], "Swap", swapValue, ""}},
+		"disk":        withBars(diskRows(info, opts, icons["disk"]), opts),
+		"diskio":      {{icons["diskio"], "Disk I/O", diskIOValue(info, opts), ""}},
+		"uptime":      {uptimeRow(info, opts, icons["uptime"])},
+		"time":        {{icons["time"], "Time", timeValue, ""}},
+		"locale":      {{icons["locale"], "Locale", info.Locale, ""}},
+		"load":        {{icons["load"], "Load", loadValue, ""}},
+		"processes":   {{icons["processes"], "Processes", processValue, ""}},
+		"network":     networkRows(info, opts, icons["network"]),
+		"localip":     {{icons["localip"], "Local IP", info.LocalIP, ""}},
+		"localipv6":   {{icons["localipv6"], "Local IPv6", info.LocalIPv6, ""}},
+		"publicip":    {{icons["publicip"], "Public IP", info.PublicIP, ""}},
+		"custom":      customRows(info, icons["custom"]),
+	}
+
+	// Metrics whose collector failed show a single N/A row
+	for key := range info.Errors {
+		if existing := rows[key]; len(existing) == 1 {
+			rows[key] = []metricRow{{existing[0].icon, existing[0].name, "N/A", ""}}
+		}
+	}
+	return rows
+}
+
+// diskRows renders one row per mountpoint, or the single root disk row when
+// no mountpoints were collected or only root is mounted
+func diskRows(info *SystemInfo, opts Options, icon string) []metricRow {
+	units := unitsFor(opts)
+	if len(info.Disks) <= 1 {
+		return []metricRow{{icon, "Disk", usageValue{units.usage(info.DiskUsed, info.Disk, info.DiskPercent), info.DiskPercent}, ""}}
+	}
+
+	rows := make([]metricRow, 0, len(info.Disks))
+	for _, d := range sortedDisks(info.Disks, opts.Sort) {
+		rows = append(rows, metricRow{
+			icon,
+			fmt.Sprintf("Disk (%s)", d.Mountpoint),
+			usageValue{units.usage(d.Used, d.Total, diskPercent(d)), diskPercent(d)},
+			"",
+		})
+	}
+	return rows
+}
+
+// diskIOValue formats the disk throughput, or returns "" when it wasn't
+// sampled
+func diskIOValue(info *SystemInfo, opts Options) string {
+	if !opts.DiskIO {
+		return ""
+	}
+	units := unitsFor(opts)
+	return fmt.Sprintf("%s read, %s write%s", units.rate(info.DiskReadMB), units.rate(info.DiskWriteMB), averageSuffix(opts))
+}
+
+// uptimeRow returns the elapsed uptime, or the boot timestamp when
+// opts.UptimeFormat asks for it
+func uptimeRow(info *SystemInfo, opts Options, icon string) metricRow {
+	if opts.UptimeFormat != UptimeBoot {
+		return metricRow{icon, "Uptime", formatUptime(info.Uptime), ""}
+	}
+
+	var booted string
+	if !info.BootTime.IsZero() {
+		booted = info.BootTime.Local().Format("2006-01-02 15:04")
+	}
+	return metricRow{icon, "Booted", booted, ""}
+}
+
+// formatUptime renders uptime in hours as e.g. "3 days, 0 hours, 31 mins"
+func formatUptime(hours float64) string {
+	// Round to whole seconds first so float error can't drop a minute
+	totalMinutes := int(math.Round(hours*3600)) / 60
+	if totalMinutes < 1 {
+		return "just now"
+	}
+
+	days := totalMinutes / (24 * 60)
+	h := (totalMinutes / 60) % 24
+	m := totalMinutes % 60
+
+	if days > 0 && h == 0 && m == 0 {
+		return plural(days, "day")
+	}
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, plural(days, "day"), plural(h, "hour"))
+	} else if h > 0 {
+		parts = append(parts, plural(h, "hour"))
+	}
+	parts = append(parts, plural(m, "min"))
+	return strings.Join(parts, ", ")
+}
+
+// plural formats n with the unit, pluralised when n != 1
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// MemoryDetail holds the memory figures shown in verbose mode, in GiB
+type MemoryDetail struct {
+	Free      float64 `json:"free_gb"`
+	Available float64 `json:"available_gb"`
+	Cached    float64 `json:"cached_gb"`
+	Buffers   float64 `json:"buffers_gb"`
+}
+
+// memoryRows renders the memory summary row, or one row per figure when
+// Options.Verbose is set
+func memoryRows(info *SystemInfo, opts Options, icon string) []metricRow {
+	units := unitsFor(opts)
+	summary := usageValue{units.usage(info.MemoryUsed, info.Memory, info.MemoryPercent), info.MemoryPercent}
+	if _, failed := info.Errors["memory"]; failed || !opts.Verbose {
+		return []metricRow{{icon, "Memory", summary, ""}}
+	}
+
+	detail := info.MemoryDetail
+	return []metricRow{
+		{icon, "Memory Total", units.size(info.Memory), ""},
+		{icon, "Memory Used", summary, ""},
+		{icon, "Memory Free", units.size(detail.Free), ""},
+		{icon, "Memory Available", units.size(detail.Available), ""},
+		{icon, "Memory Cached", units.size(detail.Cached), ""},
+		{icon, "Memory Buffers", units.size(detail.Buffers), ""},
+	}
+}
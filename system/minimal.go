@@ -0,0 +1,36 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// minimalFields are the metrics shown by PrintMinimal when Options.Fields
+// is empty
+var minimalFields = []string{"hostname", "cpu", "memory"}
+
+// PrintMinimal writes the selected metrics to w as a single pipe-separated
+// line for status bars, e.g. "vm | Intel Xeon (4 cores) | 42%". Usage
+// metrics are shortened to their percentage.
+func PrintMinimal(w io.Writer, info *SystemInfo, opts Options) {
+	if len(opts.Fields) == 0 {
+		opts.Fields = minimalFields
+	}
+
+	rows := metricRows(info, opts)
+	var parts []string
+	for _, key := range selectedKeys(opts) {
+		for _, metric := range rows[key] {
+			if metric.value == "" {
+				continue
+			}
+			if usage, ok := usageOf(metric.value); ok {
+				parts = append(parts, fmt.Sprintf("%.0f%%", usage.percent))
+				continue
+			}
+			parts = append(parts, formatValue(metric))
+		}
+	}
+	fmt.Fprintln(w, strings.Join(parts, " | "))
+}
@@ -0,0 +1,95 @@
+package system
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/fatih/color"
+)
+
+// NetIface holds traffic totals for a single network interface, in MiB
+type NetIface struct {
+	Name string  `json:"name"`
+	Sent float64 `json:"sent_mb"`
+	Recv float64 `json:"recv_mb"`
+}
+
+// collectNetwork returns the traffic of every non-loopback interface
+func collectNetwork(p Provider) ([]NetIface, error) {
+	counters, err := p.NetIOCounters(true)
+	if err != nil {
+		return nil, err
+	}
+
+	loopback := loopbackInterfaces()
+	var ifaces []NetIface
+	for _, counter := range counters {
+		if loopback[counter.Name] {
+			continue
+		}
+		ifaces = append(ifaces, NetIface{
+			Name: counter.Name,
+			Sent: float64(counter.BytesSent) / (1 << 20),
+			Recv: float64(counter.BytesRecv) / (1 << 20),
+		})
+	}
+	return ifaces, nil
+}
+
+// trafficValue is a sent/received pair, shown as "↑1.00 MiB | ↓2.00 MiB"
+// with the up arrow in green and the down arrow in blue
+type trafficValue struct {
+	sent float64
+	recv float64
+	unit string
+}
+
+func (v trafficValue) String() string {
+	return fmt.Sprintf("↑%.2f %s | ↓%.2f %s", v.sent, v.unit, v.recv, v.unit)
+}
+
+// colorize renders the value with colored arrows and the figures in c
+func (v trafficValue) colorize(c *color.Color) string {
+	return color.New(color.FgGreen).Sprint("↑") + c.Sprintf("%.2f %s | ", v.sent, v.unit) +
+		color.New(color.FgBlue).Sprint("↓") + c.Sprintf("%.2f %s", v.recv, v.unit)
+}
+
+// networkRows renders the aggregated Network row, or one row per interface
+// when Options.NetPerIface is set. Nothing is shown with Options.NoNetwork.
+func networkRows(info *SystemInfo, opts Options, icon string) []metricRow {
+	if opts.NoNetwork {
+		return nil
+	}
+	units := unitsFor(opts)
+	if !opts.NetPerIface || len(info.NetInterfaces) == 0 {
+		return []metricRow{{icon, "Network", units.traffic(info.NetworkSent, info.NetworkRecv), ""}}
+	}
+
+	rows := make([]metricRow, 0, len(info.NetInterfaces))
+	for _, iface := range sortedIfaces(info.NetInterfaces, opts.Sort) {
+		rows = append(rows, metricRow{
+			icon,
+			fmt.Sprintf("Network (%s)", iface.Name),
+			units.traffic(iface.Sent, iface.Recv),
+			"",
+		})
+	}
+	return rows
+}
+
+// loopbackInterfaces returns the names of the loopback interfaces, which
+// carry no real traffic
+func loopbackInterfaces() map[string]bool {
+	loopback := map[string]bool{"lo": true, "lo0": true}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return loopback
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			loopback[iface.Name] = true
+		}
+	}
+	return loopback
+}
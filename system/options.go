@@ -0,0 +1,111 @@
+package system
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Uptime formats accepted by Options.UptimeFormat
+const (
+	UptimeElapsed = "elapsed"
+	UptimeBoot    = "boot"
+)
+
+// Label/value separators accepted by Options.Separator
+const (
+	SeparatorColon  = ":"
+	SeparatorEquals = "="
+)
+
+// Options controls how system information is collected and displayed
+type Options struct {
+	// NoColor disables colored output
+	NoColor bool
+	// CPUUsage samples current CPU load, which blocks for one second
+	CPUUsage bool
+	// CPUBars samples per-core CPU load for the usage bars, which blocks for
+	// one second
+	CPUBars bool
+	// Metrics lists the metric keys to display, in order; nil shows every
+	// metric in the default order
+	Metrics []string
+	// Fields, when set, restricts the dashboard to these metric keys
+	Fields []string
+	// Theme names the color preset, e.g. "nord"; empty uses DefaultTheme
+	Theme string
+	// Gradient is an optional "start,end" pair of hex colors applied across
+	// the metric labels on truecolor terminals
+	Gradient string
+	// Width is the dashboard width in characters; 0 uses DefaultWidth
+	Width int
+	// Repeat is how many one second samples the sampled metrics (CPU usage,
+	// CPU bars and disk I/O) average over; 0 or 1 takes a single sample
+	Repeat int
+	// DiskIO samples disk read and write throughput, which blocks for one
+	// second
+	DiskIO bool
+	// Font adds the terminal font, read from the emulator's settings for
+	// iTerm2, gnome-terminal and Alacritty
+	Font bool
+	// Bluetooth adds the connected Bluetooth device count, which runs
+	// bluetoothctl on Linux and system_profiler on macOS
+	Bluetooth bool
+	// IPv6 adds the global IPv6 address row
+	IPv6 bool
+	// PublicIP looks up the public IP address online, which makes a network
+	// request with a two second timeout
+	PublicIP bool
+	// Sort orders the per-mountpoint Disk and per-interface Network rows:
+	// SortName, SortUsage or SortSize; empty uses SortName
+	Sort string
+	// NoNetwork skips the network traffic collector, which can be slow on
+	// systems with many interfaces
+	NoNetwork bool
+	// NetPerIface shows one Network row per interface instead of the total
+	NetPerIface bool
+	// IconSet selects the metric icons: "nerd" or "emoji"; empty uses
+	// DefaultIconSet
+	IconSet string
+	// Border wraps the dashboard in a box with the user@hostname title
+	Border bool
+	// Languages adds the installed programming languages section, which runs
+	// a version command per toolchain
+	Languages bool
+	// Verbose expands the memory summary into total, used, free, available,
+	// cached and buffer rows
+	Verbose bool
+	// Custom lists user-defined metrics collected after the built-in ones
+	Custom []CustomMetric
+	// NoIcons drops the icons in front of every metric
+	NoIcons bool
+	// UptimeFormat selects how uptime is shown: UptimeElapsed for the time
+	// since boot or UptimeBoot for the boot timestamp; empty uses
+	// UptimeElapsed
+	UptimeFormat string
+	// Bars draws a usage bar after the memory and disk figures
+	Bars bool
+	// Compact hides rows whose value is N/A, "Unknown" or all zeros
+	Compact bool
+	// Align pads the labels so every value starts in the same column
+	Align bool
+	// Separator goes between each label and its value: SeparatorColon or
+	// SeparatorEquals; empty uses SeparatorColon
+	Separator string
+	// Timings records how long each collector took in SystemInfo.Timings
+	Timings bool
+	// Units selects the memory, disk, swap and network units: UnitsBinary
+	// for GiB/MiB or UnitsDecimal for GB/MB; empty uses UnitsBinary
+	Units string
+}
+
+// CollectionKey returns a hash of the options that change what gets
+// collected, like the opt-in collectors and the sample count, so results
+// collected under different options can be told apart. Display-only options
+// don't affect it.
+func (opts Options) CollectionKey() string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v|%v|%d|%v|%v|%v|%v|%v|%v|%v|%v",
+		opts.CPUUsage, opts.CPUBars, sampleCount(opts), opts.DiskIO, opts.Font, opts.Bluetooth,
+		opts.IPv6, opts.PublicIP, opts.Languages, opts.NoNetwork, opts.Custom)
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,66 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// packageManager describes how to list installed packages for one manager
+type packageManager struct {
+	name string
+	args []string
+	// header is the number of leading output lines that aren't packages
+	header int
+}
+
+var packageManagers = []packageManager{
+	{name: "dpkg", args: []string{"dpkg-query", "-f", "${binary:Package}\n", "-W"}},
+	{name: "rpm", args: []string{"rpm", "-qa"}},
+	{name: "pacman", args: []string{"pacman", "-Qq"}},
+	{name: "apk", args: []string{"apk", "info"}},
+	{name: "xbps", args: []string{"xbps-query", "-l"}},
+	{name: "brew", args: []string{"brew", "list", "-1"}},
+	{name: "port", args: []string{"port", "installed"}, header: 1},
+	{name: "flatpak", args: []string{"flatpak", "list"}},
+	{name: "snap", args: []string{"snap", "list"}, header: 1},
+}
+
+// detectPackages counts installed packages for every package manager found on
+// the system, e.g. "1423 (dpkg), 12 (flatpak)"
+func detectPackages() string {
+	var counts []string
+	var lastErr error
+	for _, pm := range packageManagers {
+		if _, err := exec.LookPath(pm.args[0]); err != nil {
+			continue
+		}
+
+		out, err := runCommand(context.Background(), pm.args[0], pm.args[1:]...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		count := countLines(out) - pm.header
+		if count > 0 {
+			counts = append(counts, fmt.Sprintf("%d (%s)", count, pm.name))
+		}
+	}
+
+	if len(counts) == 0 {
+		return timedOutValue(lastErr, "Unknown")
+	}
+	return strings.Join(counts, ", ")
+}
+
+func countLines(s string) int {
+	count := 0
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
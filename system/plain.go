@@ -0,0 +1,47 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// PrintPlain writes one "key: value" line per metric to w, without icons,
+// colors or padding. Keys are the lowercase metric keys, so scripts can rely
+// on them; metrics with several rows (like per-mount disks) use the row
+// label made identifier-safe by plainKey instead, e.g. "disk_/home".
+func PrintPlain(w io.Writer, info *SystemInfo, opts Options) {
+	rows := metricRows(info, opts)
+	for _, key := range selectedKeys(opts) {
+		for _, metric := range rows[key] {
+			if metric.value == "" {
+				continue
+			}
+
+			name := key
+			if len(rows[key]) > 1 {
+				name = plainKey(metric.name)
+			}
+			fmt.Fprintf(w, "%s: %s\n", name, formatValue(metric))
+		}
+	}
+}
+
+// plainKey turns a row label into a key without spaces, parentheses or the
+// ":" and "=" separators, e.g. "Memory Total" into "memory_total"
+func plainKey(label string) string {
+	key := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return '_'
+		case strings.ContainsRune("():=", r):
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, label)
+	for strings.Contains(key, "__") {
+		key = strings.ReplaceAll(key, "__", "_")
+	}
+	return strings.Trim(key, "_")
+}
@@ -0,0 +1,75 @@
+package system
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPrintPlainKeys(t *testing.T) {
+	info := &SystemInfo{
+		Hostname:      "testbox",
+		Memory:        16,
+		MemoryUsed:    6,
+		MemoryPercent: 37.5,
+		Disk:          500,
+		DiskUsed:      460,
+		DiskPercent:   92,
+		Disks: []DiskInfo{
+			{Mountpoint: "/", Used: 460, Total: 500},
+			{Mountpoint: "/home", Used: 100, Total: 1000},
+		},
+		NetInterfaces: []NetIface{{Name: "eth0", Sent: 105, Recv: 310}, {Name: "wlan0", Sent: 5, Recv: 10}},
+		Custom:        []CustomValue{{Name: "Weather (Paris)", Value: "sunny"}, {Name: "Git Branch", Value: "main"}},
+	}
+	opts := Options{
+		NoColor:     true,
+		Verbose:     true,
+		NetPerIface: true,
+		Fields:      []string{"hostname", "memory", "disk", "network", "custom"},
+		Custom:      []CustomMetric{{Name: "Weather (Paris)"}, {Name: "Git Branch"}},
+	}
+
+	var out strings.Builder
+	PrintPlain(&out, info, opts)
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		key, _, _ := strings.Cut(line, ": ")
+		got = append(got, key)
+	}
+	want := []string{
+		"hostname",
+		"memory_total", "memory_used", "memory_free", "memory_available", "memory_cached", "memory_buffers",
+		"disk_/", "disk_/home",
+		"network_eth0", "network_wlan0",
+		"weather_paris", "git_branch",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("keys = %q, want %q", got, want)
+	}
+
+	safe := regexp.MustCompile(`^[a-z0-9_/.-]+$`)
+	for _, key := range got {
+		if !safe.MatchString(key) {
+			t.Errorf("key %q is not identifier-safe", key)
+		}
+	}
+}
+
+func TestPlainKey(t *testing.T) {
+	tests := map[string]string{
+		"Memory Total":        "memory_total",
+		"Disk (/home)":        "disk_/home",
+		"Network (eth0)":      "network_eth0",
+		"Cores 0-31":          "cores_0-31",
+		"  Odd:Label = x  ":   "oddlabel_x",
+		"Disk (/mnt/My Disk)": "disk_/mnt/my_disk",
+	}
+	for label, want := range tests {
+		if got := plainKey(label); got != want {
+			t.Errorf("plainKey(%q) = %q, want %q", label, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// rootPath returns the path of the system drive: "/" on Unix and the system
+// drive (usually "C:\") on Windows
+func rootPath() string {
+	if runtime.GOOS == "windows" {
+		if drive := os.Getenv("SystemDrive"); drive != "" {
+			return drive + "\\"
+		}
+		return "C:\\"
+	}
+	return "/"
+}
+
+// macOSNames maps macOS major versions (minor for 10.x) to marketing names
+var macOSNames = map[string]string{
+	"10.13": "High Sierra",
+	"10.14": "Mojave",
+	"10.15": "Catalina",
+	"11":    "Big Sur",
+	"12":    "Monterey",
+	"13":    "Ventura",
+	"14":    "Sonoma",
+	"15":    "Sequoia",
+	"26":    "Tahoe",
+}
+
+// macOSPlatform returns e.g. "macOS 14.2 Sonoma" from sw_vers, or fallback
+// when sw_vers isn't available
+func macOSPlatform(fallback string) string {
+	out, err := runCommand(context.Background(), "sw_vers", "-productVersion")
+	if err != nil {
+		return fallback
+	}
+
+	version := strings.TrimSpace(out)
+	if version == "" {
+		return fallback
+	}
+
+	parts := strings.Split(version, ".")
+	key := parts[0]
+	if key == "10" && len(parts) > 1 {
+		key = parts[0] + "." + parts[1]
+	}
+
+	if name, ok := macOSNames[key]; ok {
+		return fmt.Sprintf("macOS %s %s", version, name)
+	}
+	return "macOS " + version
+}
+
+// goarchNames maps GOARCH values to the names kernels usually report
+var goarchNames = map[string]string{
+	"amd64": "x86_64",
+	"386":   "i386",
+	"arm64": "aarch64",
+	"arm":   "arm",
+}
+
+// formatArch returns the machine architecture with its bitness, e.g.
+// "x86_64 (64-bit)". The kernel's name is preferred; the architecture the
+// binary was built for is used when it's unknown.
+func formatArch(kernelArch string) string {
+	arch := kernelArch
+	if arch == "" {
+		arch = runtime.GOARCH
+		if name, ok := goarchNames[arch]; ok {
+			arch = name
+		}
+	}
+
+	bits := 32
+	if strings.Contains(arch, "64") || arch == "s390x" {
+		bits = 64
+	}
+	return fmt.Sprintf("%s (%d-bit)", arch, bits)
+}
@@ -0,0 +1,56 @@
+package system
+
+import (
+	"time"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+	psnet "github.com/shirou/gopsutil/net"
+	"github.com/shirou/gopsutil/process"
+)
+
+// Provider is the source of the raw figures behind the core metrics.
+// CollectWithProvider accepts any implementation, so the collection logic
+// can run against canned data instead of the live system.
+type Provider interface {
+	HostInfo() (*host.InfoStat, error)
+	Uptime() (uint64, error)
+	CPUInfo() ([]cpu.InfoStat, error)
+	CPUCounts(logical bool) (int, error)
+	CPUPercent(interval time.Duration, perCPU bool) ([]float64, error)
+	VirtualMemory() (*mem.VirtualMemoryStat, error)
+	SwapMemory() (*mem.SwapMemoryStat, error)
+	DiskUsage(path string) (*disk.UsageStat, error)
+	DiskIOCounters() (map[string]disk.IOCountersStat, error)
+	NetIOCounters(perNIC bool) ([]psnet.IOCountersStat, error)
+	LoadAvg() (*load.AvgStat, error)
+	Pids() ([]int32, error)
+}
+
+// gopsutilProvider reads the live system through gopsutil
+type gopsutilProvider struct{}
+
+// defaultProvider is used by Collect, CollectWithOptions and RefreshDynamic
+var defaultProvider Provider = gopsutilProvider{}
+
+func (gopsutilProvider) HostInfo() (*host.InfoStat, error)   { return host.Info() }
+func (gopsutilProvider) Uptime() (uint64, error)             { return host.Uptime() }
+func (gopsutilProvider) CPUInfo() ([]cpu.InfoStat, error)    { return cpu.Info() }
+func (gopsutilProvider) CPUCounts(logical bool) (int, error) { return cpu.Counts(logical) }
+func (gopsutilProvider) CPUPercent(interval time.Duration, perCPU bool) ([]float64, error) {
+	return cpu.Percent(interval, perCPU)
+}
+func (gopsutilProvider) VirtualMemory() (*mem.VirtualMemoryStat, error) { return mem.VirtualMemory() }
+func (gopsutilProvider) SwapMemory() (*mem.SwapMemoryStat, error)       { return mem.SwapMemory() }
+func (gopsutilProvider) DiskUsage(path string) (*disk.UsageStat, error) { return disk.Usage(path) }
+func (gopsutilProvider) DiskIOCounters() (map[string]disk.IOCountersStat, error) {
+	return disk.IOCounters()
+}
+func (gopsutilProvider) NetIOCounters(perNIC bool) ([]psnet.IOCountersStat, error) {
+	return psnet.IOCounters(perNIC)
+}
+func (gopsutilProvider) LoadAvg() (*load.AvgStat, error) { return load.Avg() }
+func (gopsutilProvider) Pids() ([]int32, error)          { return process.Pids() }
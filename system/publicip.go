@@ -0,0 +1,41 @@
+package system
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// publicIPService returns the caller's public IP address as plain text
+const publicIPService = "https://api.ipify.org"
+
+// publicIPTimeout bounds the public IP lookup so an offline machine doesn't
+// stall the dashboard
+const publicIPTimeout = 2 * time.Second
+
+// detectPublicIP asks publicIPService for the public IP address, returning
+// "N/A" when the lookup fails or times out
+func detectPublicIP() string {
+	client := &http.Client{Timeout: publicIPTimeout}
+	resp, err := client.Get(publicIPService)
+	if err != nil {
+		return "N/A"
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "N/A"
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return "N/A"
+	}
+
+	ip := strings.TrimSpace(string(body))
+	if net.ParseIP(ip) == nil {
+		return "N/A"
+	}
+	return ip
+}
@@ -0,0 +1,320 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// remoteTimeout bounds the whole SSH session, including connecting
+const remoteTimeout = 20 * time.Second
+
+// remoteScript prints every value CollectRemote parses, one "==name=="
+// section per source. It only needs a POSIX shell and the Linux /proc files.
+const remoteScript = `
+echo ==hostname==; uname -n
+echo ==kernel==; uname -r
+echo ==arch==; uname -m
+echo ==os-release==; cat /etc/os-release
+echo ==cpuinfo==; cat /proc/cpuinfo
+echo ==meminfo==; cat /proc/meminfo
+echo ==uptime==; cat /proc/uptime
+echo ==loadavg==; cat /proc/loadavg
+echo ==df==; df -Pk /
+echo ==netdev==; cat /proc/net/dev
+echo ==processes==; ls -d /proc/[0-9]* | wc -l
+echo ==user==; id -un
+echo ==shell==; echo "$SHELL"; "$SHELL" --version </dev/null | head -n 1
+echo ==date==; date '+%s %Z'
+echo ==locale==; echo "${LC_ALL:-${LC_CTYPE:-$LANG}}"
+`
+
+// CollectRemote gathers system information from a Linux host over SSH by
+// piping a shell script to the system ssh client, with target as e.g.
+// "user@host". Only metrics that can be read from standard commands and
+// /proc are filled; local-only ones like the terminal and resolution are
+// left empty. Key-based authentication is required, since ssh runs in batch
+// mode.
+func CollectRemote(target string, opts Options) (*SystemInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	var stderr strings.Builder
+	// The script goes through stdin so it runs in sh whatever the login
+	// shell is
+	cmd := exec.CommandContext(ctx, "ssh", "-o", "BatchMode=yes", "-o", "ConnectTimeout=10", "--", target, "sh", "-s")
+	cmd.Stdin = strings.NewReader(remoteScript)
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = 100 * time.Millisecond
+
+	// The script keeps going when a single command fails, so only a failed
+	// connection (exit status 255) is fatal
+	out, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("ssh %s: %w", target, errCommandTimeout)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && (!errors.As(err, &exitErr) || exitErr.ExitCode() == 255) {
+		return nil, fmt.Errorf("ssh %s: %v: %s", target, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseRemote(splitSections(string(out)), opts), nil
+}
+
+// splitSections splits the script output into its "==name==" sections
+func splitSections(out string) map[string]string {
+	sections := make(map[string]string)
+	name := ""
+	var body strings.Builder
+	flush := func() {
+		if name != "" {
+			sections[name] = strings.TrimSpace(body.String())
+		}
+		body.Reset()
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "==") && strings.HasSuffix(line, "==") && len(line) > 4 {
+			flush()
+			name = strings.Trim(line, "=")
+			continue
+		}
+		body.WriteString(line + "\n")
+	}
+	flush()
+	return sections
+}
+
+// parseRemote builds a SystemInfo from the script sections, recording the
+// core metrics and load average that couldn't be parsed in info.Errors
+func parseRemote(sections map[string]string, opts Options) *SystemInfo {
+	info := &SystemInfo{Errors: make(map[string]string)}
+	fail := func(key, format string, args ...any) {
+		info.Errors[key] = fmt.Sprintf(format, args...)
+	}
+
+	if info.Hostname = sections["hostname"]; info.Hostname == "" {
+		fail("hostname", "failed to get host info: no hostname reported")
+	}
+	if info.Kernel = sections["kernel"]; info.Kernel == "" {
+		fail("kernel", "failed to get host info: no kernel version reported")
+	}
+	if arch := sections["arch"]; arch != "" {
+		info.Arch = formatArch(arch)
+	} else {
+		fail("arch", "failed to get host info: no architecture reported")
+	}
+	if release := parseKeyValues(sections["os-release"], "="); release["ID"] != "" {
+		info.Platform = strings.TrimSpace(release["ID"] + " " + release["VERSION_ID"])
+	} else {
+		fail("platform", "failed to get host info: /etc/os-release is missing")
+	}
+
+	if cpu, err := parseRemoteCPU(sections["cpuinfo"]); err == nil {
+		info.CPU = cpu
+	} else {
+		fail("cpu", "failed to get CPU info: %v", err)
+	}
+
+	meminfo := parseMeminfo(sections["meminfo"])
+	if total := meminfo["MemTotal"]; total > 0 {
+		// Kernels before 3.14 don't report MemAvailable
+		available, ok := meminfo["MemAvailable"]
+		if !ok {
+			available = meminfo["MemFree"] + meminfo["Buffers"] + meminfo["Cached"]
+		}
+		used := total - available
+		info.Memory = total / (1 << 30)
+		info.MemoryUsed = used / (1 << 30)
+		info.MemoryPercent = used / total * 100
+		info.MemoryDetail = MemoryDetail{
+			Free:      meminfo["MemFree"] / (1 << 30),
+			Available: available / (1 << 30),
+			Cached:    meminfo["Cached"] / (1 << 30),
+			Buffers:   meminfo["Buffers"] / (1 << 30),
+		}
+		info.SwapTotal = meminfo["SwapTotal"] / (1 << 30)
+		info.SwapUsed = (meminfo["SwapTotal"] - meminfo["SwapFree"]) / (1 << 30)
+	} else {
+		fail("memory", "failed to get memory info: /proc/meminfo is missing")
+	}
+
+	if fields := strings.Fields(sections["uptime"]); len(fields) > 0 {
+		seconds, _ := strconv.ParseFloat(fields[0], 64)
+		info.Uptime = seconds / 3600
+	}
+	if info.Uptime == 0 {
+		fail("uptime", "failed to get uptime: /proc/uptime is missing")
+	}
+
+	if err := parseRemoteDisk(info, sections["df"]); err != nil {
+		fail("disk", "failed to get disk info: %v", err)
+	}
+
+	if !opts.NoNetwork {
+		info.NetInterfaces = parseNetDev(sections["netdev"])
+		if info.NetInterfaces == nil {
+			fail("network", "failed to get network info: /proc/net/dev is missing")
+		}
+		for _, iface := range info.NetInterfaces {
+			info.NetworkSent += iface.Sent
+			info.NetworkRecv += iface.Recv
+		}
+	}
+
+	if fields := strings.Fields(sections["loadavg"]); len(fields) >= 3 {
+		for i := range info.LoadAvg {
+			info.LoadAvg[i], _ = strconv.ParseFloat(fields[i], 64)
+		}
+	} else {
+		fail("load", "failed to get load average: /proc/loadavg is missing")
+	}
+
+	// The remaining fields are best-effort
+	info.ProcessCount, _ = strconv.Atoi(sections["processes"])
+	info.User = sections["user"]
+	info.Locale = sections["locale"]
+	if lines := strings.SplitN(sections["shell"], "\n", 2); lines[0] != "" {
+		info.Shell = filepath.Base(lines[0])
+		if len(lines) == 2 {
+			if version := parseShellVersion(lines[1]); version != "" {
+				info.Shell += " " + version
+			}
+		}
+	}
+	if fields := strings.Fields(sections["date"]); len(fields) >= 1 {
+		if unix, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+			info.LocalTime = time.Unix(unix, 0)
+			if len(fields) == 2 {
+				info.TimeZone = fields[1]
+			}
+			info.BootTime = info.LocalTime.Add(-time.Duration(info.Uptime * float64(time.Hour)))
+		}
+	}
+	return info
+}
+
+// parseKeyValues parses lines like `KEY="value"`, dropping the quotes
+func parseKeyValues(text, sep string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		if key, value, ok := strings.Cut(line, sep); ok {
+			values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return values
+}
+
+// parseMeminfo returns the /proc/meminfo values in bytes
+func parseMeminfo(text string) map[string]float64 {
+	values := make(map[string]float64)
+	for key, value := range parseKeyValues(text, ":") {
+		// Values look like "16318412 kB"
+		if kb, err := strconv.ParseFloat(strings.TrimSuffix(value, " kB"), 64); err == nil {
+			values[key] = kb * 1024
+		}
+	}
+	return values
+}
+
+// parseRemoteCPU formats /proc/cpuinfo the way the local CPU collector does
+func parseRemoteCPU(text string) (string, error) {
+	var model string
+	var mhz float64
+	logical := 0
+	cores := make(map[string]bool)
+	physicalID := ""
+	for _, line := range strings.Split(text, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "processor":
+			logical++
+		case "model name", "Model":
+			if model == "" {
+				model = value
+			}
+		case "cpu MHz":
+			if mhz == 0 {
+				mhz, _ = strconv.ParseFloat(value, 64)
+			}
+		case "physical id":
+			physicalID = value
+		case "core id":
+			cores[physicalID+"/"+value] = true
+		}
+	}
+	if logical == 0 {
+		return "", fmt.Errorf("no CPUs reported")
+	}
+
+	count := fmt.Sprintf("%d cores", logical)
+	if physical := len(cores); physical > 0 && physical != logical {
+		count = fmt.Sprintf("%d physical, %d logical", physical, logical)
+	}
+	cpu := fmt.Sprintf("%s (%s)", model, count)
+	if mhz > 0 {
+		cpu += fmt.Sprintf(" @ %.2f GHz", mhz/1000)
+	}
+	return cpu, nil
+}
+
+// parseRemoteDisk fills the root filesystem usage from `df -Pk /`
+func parseRemoteDisk(info *SystemInfo, text string) error {
+	lines := strings.Split(text, "\n")
+	if len(lines) < 2 {
+		return fmt.Errorf("no df output")
+	}
+	// Filesystem 1024-blocks Used Available Capacity Mounted on
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 6 {
+		return fmt.Errorf("unexpected df output %q", lines[len(lines)-1])
+	}
+	total, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || total == 0 {
+		return fmt.Errorf("unexpected df size %q", fields[1])
+	}
+	used, _ := strconv.ParseFloat(fields[2], 64)
+	info.Disk = total / (1 << 20)
+	info.DiskUsed = used / (1 << 20)
+	info.DiskPercent = used / total * 100
+	info.Disks = []DiskInfo{{Mountpoint: fields[5], Used: info.DiskUsed, Total: info.Disk}}
+	return nil
+}
+
+// parseNetDev returns the traffic of every non-loopback interface listed in
+// /proc/net/dev
+func parseNetDev(text string) []NetIface {
+	var ifaces []NetIface
+	for _, line := range strings.Split(text, "\n") {
+		name, counters, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		// Skip the two header lines and the loopback device
+		if !ok || name == "lo" || strings.Contains(name, "|") {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) < 9 {
+			continue
+		}
+		recv, _ := strconv.ParseFloat(fields[0], 64)
+		sent, _ := strconv.ParseFloat(fields[8], 64)
+		ifaces = append(ifaces, NetIface{Name: name, Sent: sent / (1 << 20), Recv: recv / (1 << 20)})
+	}
+	if ifaces == nil && strings.Contains(text, "|") {
+		// The file exists but only lists loopback
+		return []NetIface{}
+	}
+	return ifaces
+}
@@ -0,0 +1,300 @@
+package system
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	testCPUInfo = `processor	: 0
+model name	: Intel(R) Core(TM) i5-8250U
+cpu MHz		: 2400.000
+physical id	: 0
+core id		: 0
+
+processor	: 1
+model name	: Intel(R) Core(TM) i5-8250U
+cpu MHz		: 1800.000
+physical id	: 0
+core id		: 1
+
+processor	: 2
+model name	: Intel(R) Core(TM) i5-8250U
+physical id	: 0
+core id		: 0
+
+processor	: 3
+model name	: Intel(R) Core(TM) i5-8250U
+physical id	: 0
+core id		: 1`
+
+	testMeminfo = `MemTotal:       16777216 kB
+MemFree:         2097152 kB
+MemAvailable:    8388608 kB
+Buffers:         1048576 kB
+Cached:          3145728 kB
+SwapTotal:       4194304 kB
+SwapFree:        3145728 kB`
+
+	testDF = `Filesystem     1024-blocks      Used Available Capacity Mounted on
+/dev/sda1        524288000 471859200  52428800      90% /`
+
+	testNetDev = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1048576      10    0    0    0     0          0         0    1048576      10    0    0    0     0       0          0
+  eth0:  314572800    2000    0    0    0     0          0         0  104857600    1500    0    0    0     0       0          0
+ wlan0:   10485760     100    0    0    0     0          0         0    5242880      50    0    0    0     0       0          0`
+
+	testOSRelease = `PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+ID=debian
+VERSION_ID="12"`
+)
+
+// scriptOutput joins sections the way remoteScript prints them
+func scriptOutput(sections map[string]string) string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(sections)) {
+		b.WriteString("==" + name + "==\n" + sections[name] + "\n")
+	}
+	return b.String()
+}
+
+// healthySections is the output of remoteScript on a typical Debian host
+func healthySections() map[string]string {
+	return map[string]string{
+		"hostname":   "remotebox",
+		"kernel":     "6.1.0-18-amd64",
+		"arch":       "x86_64",
+		"os-release": testOSRelease,
+		"cpuinfo":    testCPUInfo,
+		"meminfo":    testMeminfo,
+		"uptime":     "270900.00 1000000.00",
+		"loadavg":    "0.50 0.75 1.00 1/234 5678",
+		"df":         testDF,
+		"netdev":     testNetDev,
+		"processes":  "234",
+		"user":       "alice",
+		"shell":      "/bin/bash\nGNU bash, version 5.2.15(1)-release (x86_64-pc-linux-gnu)",
+		"date":       "1700000000 UTC",
+		"locale":     "en_US.UTF-8",
+	}
+}
+
+func TestSplitSections(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want map[string]string
+	}{
+		{
+			name: "sections",
+			out:  "==a==\nx\ny\n==b==\n\n==c==\n  z  \n",
+			want: map[string]string{"a": "x\ny", "b": "", "c": "z"},
+		},
+		{
+			name: "banner before first section",
+			out:  "Welcome!\n==a==\nx\n",
+			want: map[string]string{"a": "x"},
+		},
+		{
+			name: "short marker is body text",
+			out:  "==a==\n====\n",
+			want: map[string]string{"a": "===="},
+		},
+		{
+			name: "empty",
+			out:  "",
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitSections(tt.out); !maps.Equal(got, tt.want) {
+				t.Errorf("splitSections() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMeminfo(t *testing.T) {
+	got := parseMeminfo(testMeminfo + "\nHugePages_Total:       0\nbogus line")
+	want := map[string]float64{
+		"MemTotal":        16 << 30,
+		"MemFree":         2 << 30,
+		"MemAvailable":    8 << 30,
+		"Buffers":         1 << 30,
+		"Cached":          3 << 30,
+		"SwapTotal":       4 << 30,
+		"SwapFree":        3 << 30,
+		"HugePages_Total": 0,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("parseMeminfo() = %v, want %v", got, want)
+	}
+}
+
+func TestParseRemoteCPU(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "smt",
+			text: testCPUInfo,
+			want: "Intel(R) Core(TM) i5-8250U (2 physical, 4 logical) @ 2.40 GHz",
+		},
+		{
+			// ARM kernels report "Model" and no core ids or clock
+			name: "arm",
+			text: "processor\t: 0\nBogoMIPS\t: 108.00\n\nprocessor\t: 1\nBogoMIPS\t: 108.00\n\nModel\t\t: Raspberry Pi 4 Model B Rev 1.4",
+			want: "Raspberry Pi 4 Model B Rev 1.4 (2 cores)",
+		},
+		{
+			name:    "missing",
+			text:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRemoteCPU(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRemoteCPU() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRemoteCPU() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRemoteDisk(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantTotal float64
+		wantUsed  float64
+		wantErr   bool
+	}{
+		{name: "root", text: testDF, wantTotal: 500, wantUsed: 450},
+		{name: "header only", text: "Filesystem 1024-blocks Used Available Capacity Mounted on", wantErr: true},
+		{name: "truncated", text: "Filesystem\n/dev/sda1 524288000", wantErr: true},
+		{name: "zero size", text: "Filesystem\nnone 0 0 0 0% /", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &SystemInfo{}
+			err := parseRemoteDisk(info, tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRemoteDisk() error = %v, want error %v", err, tt.wantErr)
+			}
+			if info.Disk != tt.wantTotal || info.DiskUsed != tt.wantUsed {
+				t.Errorf("parseRemoteDisk() = %v/%v GiB, want %v/%v", info.DiskUsed, info.Disk, tt.wantUsed, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestParseNetDev(t *testing.T) {
+	header := strings.Join(strings.Split(testNetDev, "\n")[:2], "\n")
+	tests := []struct {
+		name string
+		text string
+		want []NetIface
+	}{
+		{
+			name: "interfaces",
+			text: testNetDev,
+			want: []NetIface{{Name: "eth0", Sent: 100, Recv: 300}, {Name: "wlan0", Sent: 5, Recv: 10}},
+		},
+		{
+			// An empty, non-nil list means the file was read
+			name: "loopback only",
+			text: header + "\n    lo:    1048576      10    0    0    0     0          0         0    1048576      10    0    0    0     0       0          0",
+			want: []NetIface{},
+		},
+		{name: "missing", text: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseNetDev(tt.text)
+			if !slices.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("parseNetDev() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRemote(t *testing.T) {
+	healthy := parseRemote(splitSections(scriptOutput(healthySections())), Options{})
+	if len(healthy.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", healthy.Errors)
+	}
+	for name, check := range map[string]struct{ got, want any }{
+		"Platform":      {healthy.Platform, "debian 12"},
+		"Hostname":      {healthy.Hostname, "remotebox"},
+		"Kernel":        {healthy.Kernel, "6.1.0-18-amd64"},
+		"Arch":          {healthy.Arch, "x86_64 (64-bit)"},
+		"CPU":           {healthy.CPU, "Intel(R) Core(TM) i5-8250U (2 physical, 4 logical) @ 2.40 GHz"},
+		"Memory":        {healthy.Memory, 16.0},
+		"MemoryUsed":    {healthy.MemoryUsed, 8.0},
+		"MemoryPercent": {healthy.MemoryPercent, 50.0},
+		"SwapUsed":      {healthy.SwapUsed, 1.0},
+		"Uptime":        {healthy.Uptime, 75.25},
+		"DiskPercent":   {healthy.DiskPercent, 90.0},
+		"NetworkSent":   {healthy.NetworkSent, 105.0},
+		"NetworkRecv":   {healthy.NetworkRecv, 310.0},
+		"LoadAvg":       {healthy.LoadAvg, [3]float64{0.5, 0.75, 1}},
+		"ProcessCount":  {healthy.ProcessCount, 234},
+		"User":          {healthy.User, "alice"},
+		"Shell":         {healthy.Shell, "bash 5.2.15"},
+		"TimeZone":      {healthy.TimeZone, "UTC"},
+		"Locale":        {healthy.Locale, "en_US.UTF-8"},
+		"BootTime":      {healthy.BootTime.Unix(), int64(1700000000 - 270900)},
+	} {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", name, check.got, check.want)
+		}
+	}
+
+	t.Run("no MemAvailable", func(t *testing.T) {
+		sections := healthySections()
+		var lines []string
+		for _, line := range strings.Split(testMeminfo, "\n") {
+			if !strings.HasPrefix(line, "MemAvailable:") {
+				lines = append(lines, line)
+			}
+		}
+		sections["meminfo"] = strings.Join(lines, "\n")
+
+		info := parseRemote(splitSections(scriptOutput(sections)), Options{})
+		// Free, buffers and cache count as available: 2 + 1 + 3 GiB
+		if info.MemoryUsed != 10 || math.Abs(info.MemoryPercent-62.5) > 1e-9 || info.MemoryDetail.Available != 6 {
+			t.Errorf("memory = %v GiB used (%v%%), %v available, want 10 (62.5%%), 6",
+				info.MemoryUsed, info.MemoryPercent, info.MemoryDetail.Available)
+		}
+	})
+
+	t.Run("no network", func(t *testing.T) {
+		info := parseRemote(splitSections(scriptOutput(healthySections())), Options{NoNetwork: true})
+		if info.NetInterfaces != nil || info.NetworkSent != 0 || len(info.Errors) != 0 {
+			t.Errorf("NetInterfaces = %v, Errors = %v, want neither", info.NetInterfaces, info.Errors)
+		}
+	})
+
+	t.Run("empty output", func(t *testing.T) {
+		info := parseRemote(splitSections(""), Options{})
+		want := []string{"arch", "cpu", "disk", "hostname", "kernel", "load", "memory", "network", "platform", "uptime"}
+		if got := info.FailedMetrics(); !slices.Equal(got, want) {
+			t.Errorf("FailedMetrics() = %v, want %v", got, want)
+		}
+		if !info.CollectionFailed(Options{}) {
+			t.Error("CollectionFailed() = false, want true")
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package system
+
+import "fmt"
+
+// sampleCount returns how many samples the sampled metrics take, at least one
+func sampleCount(opts Options) int {
+	return max(opts.Repeat, 1)
+}
+
+// averageSamples calls sample n times and returns the element-wise average of
+// the results. Samples that fail or don't match the first one's length are
+// skipped; the error is only returned when every sample fails.
+func averageSamples(n int, sample func() ([]float64, error)) ([]float64, error) {
+	var sums []float64
+	var err error
+	taken := 0
+	for i := 0; i < n; i++ {
+		values, sampleErr := sample()
+		if sampleErr != nil {
+			err = sampleErr
+			continue
+		}
+		if sums == nil {
+			sums = make([]float64, len(values))
+		}
+		if len(values) != len(sums) {
+			continue
+		}
+		for j, v := range values {
+			sums[j] += v
+		}
+		taken++
+	}
+
+	if taken == 0 {
+		if err == nil {
+			err = fmt.Errorf("no samples taken")
+		}
+		return nil, err
+	}
+	for j := range sums {
+		sums[j] /= float64(taken)
+	}
+	return sums, nil
+}
+
+// averageSuffix marks a value averaged over several samples, e.g.
+// " (avg of 3)", and is empty for a single sample
+func averageSuffix(opts Options) string {
+	if n := sampleCount(opts); n > 1 {
+		return fmt.Sprintf(" (avg of %d)", n)
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+package system
+
+import "strings"
+
+// detectResolution returns the resolution of every connected display joined
+// by ", ", "N/A" when the detection command times out, or "" when no display
+// is detected
+func detectResolution() string {
+	resolutions, err := platformResolutions()
+	if len(resolutions) == 0 {
+		return timedOutValue(err, "")
+	}
+	return strings.Join(resolutions, ", ")
+}
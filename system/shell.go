@@ -0,0 +1,73 @@
+package system
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+// detectShell returns the current shell name, with its version when it can
+// be determined (e.g. "zsh 5.9")
+func detectShell() string {
+	if runtime.GOOS == "windows" {
+		return detectWindowsShell()
+	}
+
+	shellPath := os.Getenv("SHELL")
+	if shellPath == "" {
+		return "Unknown"
+	}
+
+	name := filepath.Base(shellPath)
+	out, err := runCommand(context.Background(), shellPath, "--version")
+	if err != nil {
+		return name
+	}
+
+	firstLine := strings.SplitN(out, "\n", 2)[0]
+	if version := parseShellVersion(firstLine); version != "" {
+		return name + " " + version
+	}
+	return name
+}
+
+// parseShellVersion extracts the first version-looking token from a
+// "--version" line, e.g. "GNU bash, version 5.2.15(1)-release" -> "5.2.15"
+func parseShellVersion(line string) string {
+	for _, field := range strings.Fields(line) {
+		if field[0] < '0' || field[0] > '9' {
+			continue
+		}
+		end := strings.IndexFunc(field, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if end == -1 {
+			return field
+		}
+		return field[:end]
+	}
+	return ""
+}
+
+// detectWindowsShell prefers the parent process (e.g. powershell.exe) and
+// falls back to the ComSpec interpreter
+func detectWindowsShell() string {
+	if parent, err := process.NewProcess(int32(os.Getppid())); err == nil {
+		if name, err := parent.Name(); err == nil {
+			name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+			switch name {
+			case "powershell", "pwsh", "cmd", "bash", "nu":
+				return name
+			}
+		}
+	}
+
+	if comSpec := os.Getenv("ComSpec"); comSpec != "" {
+		return strings.TrimSuffix(strings.ToLower(filepath.Base(comSpec)), ".exe")
+	}
+	return "Unknown"
+}
@@ -0,0 +1,53 @@
+package system
+
+import "sort"
+
+// Row orders accepted by Options.Sort for disks and network interfaces
+const (
+	SortName  = "name"
+	SortUsage = "usage"
+	SortSize  = "size"
+)
+
+// sortedDisks returns a copy of disks ordered by mountpoint, by usage
+// percentage (highest first) or by total size (largest first)
+func sortedDisks(disks []DiskInfo, by string) []DiskInfo {
+	sorted := append([]DiskInfo(nil), disks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch by {
+		case SortUsage:
+			return diskPercent(a) > diskPercent(b)
+		case SortSize:
+			return a.Total > b.Total
+		default:
+			return a.Mountpoint < b.Mountpoint
+		}
+	})
+	return sorted
+}
+
+// diskPercent returns the used share of d in percent, or 0 for a disk with
+// no size, where the division would give NaN and break the sort order
+func diskPercent(d DiskInfo) float64 {
+	if d.Total == 0 {
+		return 0
+	}
+	return d.Used / d.Total * 100
+}
+
+// sortedIfaces returns a copy of ifaces ordered by name, or by total traffic
+// (busiest first) for SortUsage and SortSize
+func sortedIfaces(ifaces []NetIface, by string) []NetIface {
+	sorted := append([]NetIface(nil), ifaces...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch by {
+		case SortUsage, SortSize:
+			return a.Sent+a.Recv > b.Sent+b.Recv
+		default:
+			return a.Name < b.Name
+		}
+	})
+	return sorted
+}
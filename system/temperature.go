@@ -0,0 +1,22 @@
+package system
+
+import (
+	"strings"
+
+	"github.com/shirou/gopsutil/host"
+)
+
+// detectCPUTemp returns the CPU temperature in °C, or 0 when no CPU sensor is
+// available (common in VMs)
+func detectCPUTemp() float64 {
+	// SensorsTemperatures can return readings alongside a warning error, so
+	// the readings are used whenever there are any
+	sensors, _ := host.SensorsTemperatures()
+	for _, sensor := range sensors {
+		key := strings.ToLower(sensor.SensorKey)
+		if (strings.Contains(key, "coretemp") || strings.Contains(key, "cpu")) && sensor.Temperature > 0 {
+			return sensor.Temperature
+		}
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+// termPrograms maps TERM_PROGRAM values to display names
+var termPrograms = map[string]string{
+	"iTerm.app":      "iTerm2",
+	"Apple_Terminal": "Apple Terminal",
+	"vscode":         "VS Code",
+	"WezTerm":        "WezTerm",
+	"Hyper":          "Hyper",
+	"tmux":           "tmux",
+	"ghostty":        "Ghostty",
+}
+
+// knownTerminals maps emulator process names to display names
+var knownTerminals = map[string]string{
+	"gnome-terminal-server": "gnome-terminal",
+	"gnome-terminal":        "gnome-terminal",
+	"konsole":               "Konsole",
+	"alacritty":             "Alacritty",
+	"kitty":                 "kitty",
+	"xterm":                 "xterm",
+	"urxvt":                 "urxvt",
+	"rxvt":                  "rxvt",
+	"st":                    "st",
+	"terminator":            "Terminator",
+	"tilix":                 "Tilix",
+	"xfce4-terminal":        "xfce4-terminal",
+	"mate-terminal":         "mate-terminal",
+	"lxterminal":            "LXTerminal",
+	"foot":                  "foot",
+	"wezterm-gui":           "WezTerm",
+	"ghostty":               "Ghostty",
+	"windowsterminal":       "Windows Terminal",
+	"ptyxis":                "Ptyxis",
+	"tmux: server":          "tmux",
+	"screen":                "screen",
+}
+
+// detectTerminal returns the terminal emulator name, falling back to the
+// TERM value when the emulator can't be identified
+func detectTerminal() string {
+	if program := os.Getenv("TERM_PROGRAM"); program != "" {
+		if name, ok := termPrograms[program]; ok {
+			return name
+		}
+		return program
+	}
+
+	if os.Getenv("WT_SESSION") != "" {
+		return "Windows Terminal"
+	}
+
+	if name := terminalFromParents(); name != "" {
+		return name
+	}
+
+	if term := os.Getenv("TERM"); term != "" {
+		return term
+	}
+	return "Unknown"
+}
+
+// terminalFromParents walks up the process tree looking for a known
+// terminal emulator
+func terminalFromParents() string {
+	pid := int32(os.Getppid())
+	// Guard against cycles and very deep trees
+	for depth := 0; depth < 16 && pid > 1; depth++ {
+		p, err := process.NewProcess(pid)
+		if err != nil {
+			return ""
+		}
+
+		if name, err := p.Name(); err == nil {
+			name = strings.TrimSuffix(strings.ToLower(filepath.Base(name)), ".exe")
+			if terminal, ok := knownTerminals[name]; ok {
+				return terminal
+			}
+		}
+
+		ppid, err := p.Ppid()
+		if err != nil || ppid == pid {
+			return ""
+		}
+		pid = ppid
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ng-fetch/ascii"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
+
+// themeFile mirrors a user theme file, e.g.
+// ~/.config/ng-fetch/themes/gruvbox.yaml. Colors left out fall back to the
+// default theme.
+type themeFile struct {
+	Header  string            `yaml:"header"`
+	Section string            `yaml:"section"`
+	Value   string            `yaml:"value"`
+	Border  string            `yaml:"border"`
+	Icons   map[string]string `yaml:"icons"`
+}
+
+// themeIcons maps theme names to the per-metric icons they override
+var themeIcons = map[string]map[string]string{}
+
+// themeStyles maps the text styles accepted in theme files to attributes
+var themeStyles = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+}
+
+// LoadThemes registers every *.yaml theme in dir under its file name, so
+// "gruvbox.yaml" is selected with Options.Theme "gruvbox". A file named
+// after a preset replaces it. Themes that fail to parse are skipped and
+// reported together in the returned error; a missing dir isn't an error.
+func LoadThemes(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, path := range paths {
+		name := strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".yaml"))
+		schemes, icons, err := loadThemeFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse theme %s: %v", path, err))
+			continue
+		}
+		themes[name] = schemes
+		themeIcons[name] = icons
+	}
+	return errors.Join(errs...)
+}
+
+// loadThemeFile reads one theme file, validating its colors and icon keys
+func loadThemeFile(path string) (colorSchemes, map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return colorSchemes{}, nil, err
+	}
+
+	var file themeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return colorSchemes{}, nil, err
+	}
+
+	schemes := themes[DefaultTheme]
+	for _, field := range []struct {
+		key   string
+		value string
+		dst   **color.Color
+	}{
+		{"header", file.Header, &schemes.header},
+		{"section", file.Section, &schemes.section},
+		{"value", file.Value, &schemes.value},
+		{"border", file.Border, &schemes.border},
+	} {
+		if field.value == "" {
+			continue
+		}
+		c, err := parseThemeColor(field.value)
+		if err != nil {
+			return colorSchemes{}, nil, fmt.Errorf("%s: %v", field.key, err)
+		}
+		*field.dst = c
+	}
+
+	// Metric rows look icons up by lowercase key
+	icons := make(map[string]string, len(file.Icons))
+	for key, icon := range file.Icons {
+		if !IsMetricKey(key) {
+			return colorSchemes{}, nil, fmt.Errorf("icon for unknown metric %q", key)
+		}
+		icons[strings.ToLower(key)] = icon
+	}
+	return schemes, icons, nil
+}
+
+// parseThemeColor parses a color accepted by ascii.ParseColor, optionally
+// preceded by styles, e.g. "bold #ff79c6"
+func parseThemeColor(value string) (*color.Color, error) {
+	words := strings.Fields(strings.ToLower(value))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty color")
+	}
+
+	c, err := ascii.ParseColor(words[len(words)-1])
+	if err != nil {
+		return nil, err
+	}
+	for _, style := range words[:len(words)-1] {
+		attr, ok := themeStyles[style]
+		if !ok {
+			return nil, fmt.Errorf("unknown style %q in %q (valid styles: bold, italic, underline)", style, value)
+		}
+		c.Add(attr)
+	}
+	return c, nil
+}
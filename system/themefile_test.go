@@ -0,0 +1,44 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadThemeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mixed.yaml")
+	theme := "header: bold bright-blue\nvalue: \"#ff8800\"\nicons:\n  CPU: C\n  Memory: M\n"
+	if err := os.WriteFile(path, []byte(theme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, icons, err := loadThemeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if icons["cpu"] != "C" || icons["memory"] != "M" || len(icons) != 2 {
+		t.Errorf("icons = %v, want lowercase keys cpu and memory", icons)
+	}
+}
+
+func TestParseThemeColor(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "cyan"},
+		{value: "bright-magenta"},
+		{value: "#ff8800"},
+		{value: "Bold Underline #FF79C6"},
+		{value: "", wantErr: true},
+		{value: "blink cyan", wantErr: true},
+		{value: "#ff88", wantErr: true},
+		{value: "teal", wantErr: true},
+	}
+	for _, tt := range tests {
+		if _, err := parseThemeColor(tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("parseThemeColor(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
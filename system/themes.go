@@ -0,0 +1,64 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// DefaultTheme is the theme used when none is selected
+const DefaultTheme = "default"
+
+// themes maps each preset name to its colors. They stick to the 16 ANSI
+// colors so every terminal can show them.
+var themes = map[string]colorSchemes{
+	"default": {
+		header:  color.New(color.FgHiGreen, color.Bold),
+		section: color.New(color.FgHiBlue, color.Bold),
+		value:   color.New(color.FgWhite),
+		border:  color.New(color.FgHiBlack, color.Bold),
+	},
+	"dracula": {
+		header:  color.New(color.FgHiMagenta, color.Bold),
+		section: color.New(color.FgHiCyan, color.Bold),
+		value:   color.New(color.FgHiWhite),
+		border:  color.New(color.FgMagenta),
+	},
+	"nord": {
+		header:  color.New(color.FgHiCyan, color.Bold),
+		section: color.New(color.FgBlue, color.Bold),
+		value:   color.New(color.FgWhite),
+		border:  color.New(color.FgHiBlue),
+	},
+	"solarized": {
+		header:  color.New(color.FgYellow, color.Bold),
+		section: color.New(color.FgCyan, color.Bold),
+		value:   color.New(color.FgHiCyan),
+		border:  color.New(color.FgHiGreen),
+	},
+}
+
+// ThemeNames returns the names of every theme preset in alphabetical order
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupTheme returns the preset with the given name (case-insensitive),
+// falling back to the default theme when name is empty
+func lookupTheme(name string) (colorSchemes, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	schemes, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return colorSchemes{}, fmt.Errorf("unknown theme %q (valid themes: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return schemes, nil
+}
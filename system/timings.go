@@ -0,0 +1,34 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// CollectorTiming is how long a single collector took
+type CollectorTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// PrintTimings writes the total collection time followed by the time of
+// every collector recorded in info, slowest first. Info served from the
+// cache has no collector timings, so only the total is shown.
+func PrintTimings(w io.Writer, info *SystemInfo, total time.Duration) {
+	fmt.Fprintf(w, "Collection took %s\n", total.Round(time.Millisecond))
+
+	timings := append([]CollectorTiming(nil), info.Timings...)
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Duration > timings[j].Duration
+	})
+
+	width := 0
+	for _, timing := range timings {
+		width = max(width, len(timing.Name))
+	}
+	for _, timing := range timings {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, timing.Name, timing.Duration.Round(10*time.Microsecond))
+	}
+}
@@ -0,0 +1,47 @@
+package system
+
+import "fmt"
+
+// Size units accepted by Options.Units. The figures are collected in
+// 1024-based units, so binary shows them as is.
+const (
+	UnitsBinary  = "binary"
+	UnitsDecimal = "decimal"
+)
+
+// sizeUnits converts the collected GiB and MiB figures into the selected
+// units and labels them
+type sizeUnits struct {
+	gb, mb           float64 // factors applied to GiB and MiB figures
+	gbLabel, mbLabel string
+}
+
+// unitsFor returns the units selected by opts.Units: GiB/MiB for binary,
+// GB/MB with 1000-based figures for decimal
+func unitsFor(opts Options) sizeUnits {
+	if opts.Units == UnitsDecimal {
+		return sizeUnits{gb: (1 << 30) / 1e9, mb: (1 << 20) / 1e6, gbLabel: "GB", mbLabel: "MB"}
+	}
+	return sizeUnits{gb: 1, mb: 1, gbLabel: "GiB", mbLabel: "MiB"}
+}
+
+// size formats a figure in GiB, e.g. "7.80 GiB"
+func (u sizeUnits) size(gib float64) string {
+	return fmt.Sprintf("%.2f %s", gib*u.gb, u.gbLabel)
+}
+
+// usage formats used and total GiB with the percentage, e.g.
+// "5.00 GiB / 8.00 GiB (62%)"
+func (u sizeUnits) usage(used, total, percent float64) string {
+	return fmt.Sprintf("%s / %s (%.0f%%)", u.size(used), u.size(total), percent)
+}
+
+// rate formats a throughput in MiB/s, e.g. "1.50 MiB/s"
+func (u sizeUnits) rate(mib float64) string {
+	return fmt.Sprintf("%.2f %s/s", mib*u.mb, u.mbLabel)
+}
+
+// traffic returns a sent/received pair given in MiB
+func (u sizeUnits) traffic(sent, recv float64) trafficValue {
+	return trafficValue{sent * u.mb, recv * u.mb, u.mbLabel}
+}
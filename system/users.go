@@ -0,0 +1,33 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shirou/gopsutil/host"
+)
+
+// detectUsers returns the logged-in users with their count, e.g.
+// "alice, bob (2)". Users with several sessions are listed once. It returns
+// an empty string when sessions can't be read or nobody is logged in.
+func detectUsers() string {
+	sessions, err := host.Users()
+	if err != nil {
+		return ""
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, session := range sessions {
+		if session.User == "" || seen[session.User] {
+			continue
+		}
+		seen[session.User] = true
+		names = append(names, session.User)
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d)", strings.Join(names, ", "), len(names))
+}
@@ -0,0 +1,58 @@
+package system
+
+import (
+	"strings"
+
+	"github.com/shirou/gopsutil/host"
+)
+
+// hypervisorNames maps the systems gopsutil reports for guests to display
+// names; container runtimes are left out since the Container row covers them
+var hypervisorNames = map[string]string{
+	"kvm":    "KVM",
+	"xen":    "Xen",
+	"vbox":   "VirtualBox",
+	"vmware": "VMware",
+	"hyperv": "Hyper-V",
+	"qemu":   "QEMU",
+	"bhyve":  "bhyve",
+}
+
+// dmiHypervisors maps substrings of the DMI product and board strings to
+// hypervisors, checked in order
+var dmiHypervisors = []struct {
+	marker string
+	name   string
+}{
+	{"virtualbox", "VirtualBox"},
+	{"vmware", "VMware"},
+	{"kvm", "KVM"},
+	{"qemu", "QEMU"},
+	{"standard pc (", "QEMU"},
+	{"hvm domu", "Xen"},
+	{"microsoft corporation virtual machine", "Hyper-V"},
+	{"virtual machine", "Hyper-V"},
+	{"parallels", "Parallels"},
+	{"bhyve", "bhyve"},
+}
+
+// detectVirtualization returns the hypervisor ng-fetch runs under, e.g.
+// "KVM", or "" on bare metal or when it can't be identified
+func detectVirtualization() string {
+	if system, role, err := host.Virtualization(); err == nil && role == "guest" {
+		if name, ok := hypervisorNames[system]; ok {
+			return name
+		}
+	}
+
+	hardware := strings.ToLower(joinNonEmpty(platformModel(), platformMotherboard()))
+	if hardware == "" {
+		return ""
+	}
+	for _, entry := range dmiHypervisors {
+		if strings.Contains(hardware, entry.marker) {
+			return entry.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package system
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// detectWSL returns "WSL1" or "WSL2" when running under the Windows
+// Subsystem for Linux, or "" otherwise. WSL kernels report "Microsoft" in
+// their version: WSL1 as e.g. "4.4.0-19041-Microsoft" and WSL2 as e.g.
+// "5.15.153.1-microsoft-standard-WSL2".
+func detectWSL(kernel string) string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+
+	version := kernel
+	if data, err := os.ReadFile("/proc/version"); err == nil {
+		version += " " + string(data)
+	}
+	version = strings.ToLower(version)
+
+	switch {
+	case !strings.Contains(version, "microsoft"):
+		return ""
+	case strings.Contains(version, "wsl2") || strings.Contains(version, "microsoft-standard"):
+		return "WSL2"
+	default:
+		return "WSL1"
+	}
+}
@@ -1,22 +1,42 @@
 package utils
 
-import "github.com/fatih/color"
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
 
 const (
-	Green = "green"
-	Blue  = "blue"
-	Red   = "red"
+	Green   = "green"
+	Blue    = "blue"
+	Red     = "red"
+	Yellow  = "yellow"
+	Cyan    = "cyan"
+	Magenta = "magenta"
+	White   = "white"
 )
 
-func PrintColored(text, colorType string) {
-	switch colorType {
-	case Green:
-		color.Green(text)
-	case Blue:
-		color.Blue(text)
-	case Red:
-		color.Red(text)
-	default:
-		color.White(text)
+// foregrounds maps color names to their foreground attributes
+var foregrounds = map[string]color.Attribute{
+	Green:   color.FgGreen,
+	Blue:    color.FgBlue,
+	Red:     color.FgRed,
+	Yellow:  color.FgYellow,
+	Cyan:    color.FgCyan,
+	Magenta: color.FgMagenta,
+	White:   color.FgWhite,
+}
+
+// PrintColored prints text on its own line in the named color. Extra
+// attributes such as color.Bold, color.Underline or a background like
+// color.BgBlue are applied on top. Unknown color names return an error
+// without printing anything.
+func PrintColored(text, colorType string, attrs ...color.Attribute) error {
+	fg, ok := foregrounds[colorType]
+	if !ok {
+		return fmt.Errorf("unknown color %q", colorType)
 	}
+
+	color.New(append([]color.Attribute{fg}, attrs...)...).Println(text)
+	return nil
 }